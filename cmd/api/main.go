@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"project/internal/adapters/handlers"
@@ -8,18 +9,25 @@ import (
 	"project/internal/core/usecases"
 	"project/internal/infrastructure/auth"
 	"project/internal/infrastructure/cache"
+	"project/internal/infrastructure/circuitbreaker"
 	"project/internal/infrastructure/config"
 	"project/internal/infrastructure/error_handler"
+	"project/internal/infrastructure/jobs"
 	"project/internal/infrastructure/messaging"
+	"project/internal/infrastructure/quota"
 	"project/internal/infrastructure/repository"
+	"project/internal/infrastructure/retry"
 	"project/internal/infrastructure/routes"
 	"project/internal/infrastructure/sessions"
+	"time"
 )
 
+var oauthConfig auth.OAuthConfig
+
 func init() {
 	config.InitEnvConfig()
 
-	auth.InitGoth(auth.OAuthConfig{
+	oauthConfig = auth.OAuthConfig{
 		ClientID:     config.EnvConfigs.ClientID,
 		ClientSecret: config.EnvConfigs.SecretKey,
 		RedirectURL:  "http://localhost:8080/auth/google/callback",
@@ -32,7 +40,8 @@ func init() {
 			"email",
 			"profile",
 		},
-	})
+	}
+	auth.InitGoth(oauthConfig)
 }
 
 func main() {
@@ -44,30 +53,88 @@ func main() {
 
 	// Serviço de autenticação
 	authService := &services.GothAuthService{}
-	authHandler := handlers.NewHandler(authService, sessionManager, userRepository)
 
-	// Instancie o cache e o repositório
-	redisCache := cache.NewRedisCache("localhost:6379")
-	repo := repository.NewPlaylistRepositoryRedis(redisCache)
+	// Instancie o cache (Redis ou em memória, por CACHE_BACKEND) e o repositório
+	redisCache, err := cache.New(config.CacheOptions())
+	if err != nil {
+		log.Fatalf("cache: %v", err)
+	}
+	// Hits, misses, erros e latência do cache e do repositório, expostos em /admin/cache/metrics
+	cacheMetrics := cache.NewMetrics()
+	redisCache = cache.WithMetrics(redisCache, cacheMetrics)
+	// Formato dos registros no Redis (CACHE_CODEC); a leitura aceita qualquer um dos formatos
+	cacheCodec, err := repository.NewCacheCodec(config.EnvConfigs.CacheCodec)
+	if err != nil {
+		log.Fatalf("cache codec: %v", err)
+	}
+	// Metadados de vídeos são compartilhados entre usuários e expiram depois de VIDEO_CACHE_TTL_SECONDS
+	videoRepo := repository.NewVideoRepositoryRedis(redisCache, cacheCodec, time.Duration(config.EnvConfigs.VideoCacheTTLSeconds)*time.Second)
+	// Listagens e vídeos expiram depois de CACHE_TTL_SECONDS; passados CACHE_FRESH_SECONDS são revalidados em segundo plano
+	repo := repository.WithMetrics(repository.NewPlaylistRepositoryRedis(redisCache, videoRepo, cacheCodec, time.Duration(config.EnvConfigs.CacheTTLSeconds)*time.Second), cacheMetrics)
 
-	producer := messaging.NewRabbitMQProducer("reorderApi")
+	// Fila das ações adiadas (RabbitMQ, em memória ou no Postgres, por JOB_BACKEND)
+	jobQueue, err := messaging.New(messaging.Options{
+		Backend: config.EnvConfigs.JobBackend,
+		AMQPURL: config.EnvConfigs.AmqpURL,
+		Queue:   config.EnvConfigs.JobQueue,
+		DB:      dbConn,
+	})
+	if err != nil {
+		log.Fatalf("fila: %v", err)
+	}
+	defer jobQueue.Close()
+	// Estado dos jobs assíncronos no Postgres, consultado por qualquer réplica em GET /jobs/{id}.
+	// Com a fila em memória os jobs também ficam no processo, que é o único a consumi-la.
+	jobStore := jobs.NewMemoryStore()
+	if config.EnvConfigs.JobBackend != messaging.BackendMemory {
+		jobStore, err = jobs.NewPostgresStore(dbConn)
+		if err != nil {
+			log.Fatalf("jobs: %v", err)
+		}
+	}
 
 	// Instancie o tratador de erros (via inversão de dependência)
-	errHandler := error_handler.NewErrorHandler(producer)
+	errHandler := error_handler.NewErrorHandler(jobQueue)
+	// Contabilização da cota do YouTube por usuário e dia
+	quotaLedger := quota.NewLedger(redisCache, quota.Budget{
+		Global:  config.EnvConfigs.QuotaDailyLimit,
+		PerUser: config.EnvConfigs.QuotaUserDailyLimit,
+	})
+	// Clientes do YouTube por usuário, com renovação automática do token
+	youtubeClients := auth.NewYoutubeClientProvider(userRepository, oauthConfig, quotaLedger)
+	// O logout descarta o cliente do YouTube do usuário
+	authHandler := handlers.NewHandler(authService, sessionManager, userRepository, youtubeClients)
 	// Serviço de playlists
-	youtubeService := services.NewYoutubePlaylistService(repo, errHandler, sessionManager)
-	// Caso de uso para reordenar playlist
-	reorderUseCase := usecases.NewReorderPlaylistUseCase(youtubeService)
+	// Retentativas com backoff exponencial para erros transitórios do YouTube
+	retrier := retry.NewRetrier(retry.DefaultPolicies(), retry.Policy{})
+	// Circuit breaker por família de endpoints; só erros transitórios contam como falha
+	breakers := circuitbreaker.NewRegistry(circuitbreaker.Settings{
+		FailureThreshold: config.EnvConfigs.BreakerFailureThreshold,
+		OpenTimeout:      time.Duration(config.EnvConfigs.BreakerOpenSeconds) * time.Second,
+	}, retry.IsTransient)
+	youtubeService := services.NewYoutubePlaylistService(repo, videoRepo, youtubeClients, errHandler, quotaLedger, retrier, breakers, jobs.NewProgressReporter(), config.EnvConfigs.YoutubeFetchConcurrency, time.Duration(config.EnvConfigs.CacheFreshSeconds)*time.Second)
+	// Caso de uso para reordenar playlist: enfileira o job e o consumidor faz a reordenação
+	reorderUseCase := usecases.NewReorderPlaylistUseCase(messaging.NewJobScheduler(jobQueue, jobStore))
 	// Handler para operações de playlist
 	reorderPlaylist := handlers.NewPlaylistHandler(reorderUseCase, sessionManager)
-	getAllPlaylists := handlers.NewGetAllPlaylistsHandler(youtubeService, sessionManager, userRepository)
+	getAllPlaylistsUseCase := usecases.NewGetAllPlaylistsUseCase(youtubeService)
+	getAllPlaylists := handlers.NewGetAllPlaylistsHandler(getAllPlaylistsUseCase, sessionManager, userRepository)
+	getPlaylistVideosUseCase := usecases.NewGetPlaylistVideosUseCase(youtubeService)
+	getPlaylistVideos := handlers.NewGetPlaylistVideosHandler(getPlaylistVideosUseCase, sessionManager)
+	quotaHandler := handlers.NewQuotaHandler(quotaLedger, sessionManager)
+	statusHandler := handlers.NewStatusHandler(breakers)
+	cacheAdminHandler := handlers.NewCacheAdminHandler(repo, cacheMetrics)
+	deadLetterHandler := handlers.NewDeadLetterHandler(jobQueue)
+	jobStatusHandler := handlers.NewJobStatusHandler(jobStore, sessionManager)
 
-	// Startanto RabbitMQConsumer
-	consumer := messaging.NewRabbitMQConsumer(youtubeService, errHandler)
-	go consumer.StartRabbitMQConsumer("reorderApi")
+	// Startanto o consumidor da fila
+	consumer := messaging.NewConsumer(messaging.NewDispatcher(youtubeService), jobQueue, config.EnvConfigs.JobMaxAttempts)
+	consumer.Jobs = jobStore
+	consumer.Completed = messaging.NewCompletedStore(redisCache, 0)
+	go consumer.Start(context.Background())
 
 	// Configuração das rotas com Gorilla/mux
-	router := routes.ConfigureRoutes(authHandler, reorderPlaylist, getAllPlaylists, sessionManager, authService, userRepository)
+	router := routes.ConfigureRoutes(authHandler, reorderPlaylist, getAllPlaylists, getPlaylistVideos, quotaHandler, statusHandler, cacheAdminHandler, deadLetterHandler, jobStatusHandler, config.EnvConfigs.AdminToken, sessionManager, authService, userRepository)
 
 	log.Println("API iniciada na porta 8080")
 	log.Fatal(http.ListenAndServe(":8080", router))
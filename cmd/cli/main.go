@@ -1 +1,82 @@
-package cli
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"project/internal/DTOs"
+	"project/internal/infrastructure/cache"
+	"project/internal/infrastructure/config"
+	"project/internal/infrastructure/repository"
+	"strings"
+)
+
+const usage = `uso: cli <comando> [opções]
+
+comandos:
+  migrate-cache   atualiza os registros do Redis para a versão atual do schema e remove os incompatíveis
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "migrate-cache":
+		migrateCache(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// migrateCache usa a mesma configuração da API (app.env), para enxergar as chaves com o
+// mesmo prefixo e credenciais; as flags sobrescrevem o endereço, o prefixo e o codec.
+func migrateCache(args []string) {
+	flags := flag.NewFlagSet("migrate-cache", flag.ExitOnError)
+	address := flags.String("redis", "", "endereços do Redis separados por vírgula (padrão: REDIS_ADDRESS)")
+	prefix := flags.String("prefix", "", "prefixo das chaves (padrão: CACHE_KEY_PREFIX)")
+	codecName := flags.String("codec", "", "formato em que os registros são regravados, json ou gzip (padrão: CACHE_CODEC)")
+	dryRun := flags.Bool("dry-run", false, "apenas conta o que seria atualizado ou removido")
+	flags.Parse(args)
+
+	config.InitEnvConfig()
+	options := config.CacheOptions()
+	// A migração só faz sentido contra o Redis: o backend em memória vive dentro de cada processo.
+	options.Backend = cache.BackendRedis
+	if *address != "" {
+		options.Redis.Addresses = strings.Split(*address, ",")
+	}
+	if *prefix != "" {
+		options.KeyPrefix = *prefix
+	}
+	if *codecName == "" {
+		*codecName = config.EnvConfigs.CacheCodec
+	}
+
+	codec, err := repository.NewCacheCodec(*codecName)
+	if err != nil {
+		log.Fatalf("migrate-cache: %v", err)
+	}
+
+	client, err := cache.New(options)
+	if err != nil {
+		log.Fatalf("migrate-cache: %v", err)
+	}
+
+	migrator := repository.NewCacheMigrator(client, codec)
+	report, err := migrator.Migrate(context.Background(), *dryRun)
+	if err != nil {
+		log.Fatalf("migrate-cache: %v", err)
+	}
+
+	action := "atualizados"
+	if *dryRun {
+		action = "a atualizar"
+	}
+	fmt.Printf("schema v%d (%s): %d registros lidos, %d %s, %d removidos\n", DTOs.CacheSchemaVersion, codec.Name(), report.Scanned, report.Upgraded, action, report.Purged)
+}
@@ -1,10 +1,21 @@
 package DTOs
 
+import "encoding/json"
+
 type PlaylistActionDTO struct {
 	ActionName string `json:"action_name"`
 	PlaylistId string `json:"playlist_id"`
-	Params     string `json:"params;omitempty"`
-	Err        string `json:"err"`
-	RetryAt    int64  `json:"retry_at"`
-	UserId     string `json:"user_id"`
+	// Params guarda os parâmetros tipados da ação (ReorderParamsDTO, AddVideoParamsDTO...).
+	Params  json.RawMessage `json:"params,omitempty"`
+	Err     string          `json:"err"`
+	RetryAt int64           `json:"retry_at"`
+	UserId  string          `json:"user_id"`
+	// RequestId é o X-Request-Id da requisição que originou a ação.
+	RequestId string `json:"request_id,omitempty"`
+	// IdempotencyKey identifica o mesmo job entre reentregas e novos adiamentos.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// JobId liga a ação ao status consultado em GET /jobs/{id}; vazio nas ações sem job.
+	JobId string `json:"job_id,omitempty"`
+	// Attempts conta as execuções que já falharam; viaja na mensagem para sobreviver às reentregas.
+	Attempts int `json:"attempts"`
 }
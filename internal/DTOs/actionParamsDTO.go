@@ -0,0 +1,110 @@
+package DTOs
+
+import (
+	"errors"
+	"fmt"
+
+	"project/internal/core/entities"
+)
+
+// Parâmetros de cada ação enfileirada. As ações que só precisam da playlist e do usuário
+// (listagens, vídeos da playlist, remoção) não têm parâmetros.
+
+// reorderCriteria são os critérios que o serviço de playlists sabe aplicar.
+var reorderCriteria = map[string]bool{
+	"byTitle":       true,
+	"byPublishedAt": true,
+	"byDuration":    true,
+	"byChannel":     true,
+	"byLanguage":    true,
+}
+
+type ReorderParamsDTO struct {
+	Criteria string             `json:"criteria"`
+	Options  PlaylistOptionsDTO `json:"options"`
+}
+
+func (dto ReorderParamsDTO) Validate() error {
+	if dto.Criteria == "" {
+		return errors.New("critério de ordenação ausente")
+	}
+	if !reorderCriteria[dto.Criteria] {
+		return fmt.Errorf("critério de ordenação desconhecido: %q", dto.Criteria)
+	}
+	return nil
+}
+
+type VideoDetailsParamsDTO struct {
+	VideoIds []string `json:"video_ids"`
+}
+
+func (dto VideoDetailsParamsDTO) Validate() error {
+	if len(dto.VideoIds) == 0 {
+		return errors.New("nenhum vídeo informado")
+	}
+	return nil
+}
+
+// CreatePlaylistParamsDTO descreve a cópia a criar: os vídeos, na ordem, e as configurações já resolvidas.
+type CreatePlaylistParamsDTO struct {
+	ChannelId string              `json:"channel_id"`
+	VideoIds  []string            `json:"video_ids"`
+	Settings  PlaylistSettingsDTO `json:"settings"`
+}
+
+func (dto CreatePlaylistParamsDTO) Validate() error {
+	if dto.Settings.Title == "" {
+		return errors.New("título da playlist ausente")
+	}
+	return nil
+}
+
+type AddVideoParamsDTO struct {
+	VideoId string `json:"video_id"`
+}
+
+func (dto AddVideoParamsDTO) Validate() error {
+	if dto.VideoId == "" {
+		return errors.New("vídeo ausente")
+	}
+	return nil
+}
+
+type UpdatePlaylistParamsDTO struct {
+	Settings PlaylistSettingsDTO `json:"settings"`
+}
+
+func (dto UpdatePlaylistParamsDTO) Validate() error {
+	if dto.Settings.Title == "" {
+		return errors.New("título da playlist ausente")
+	}
+	return nil
+}
+
+type PlaylistSettingsDTO struct {
+	Title           string   `json:"title"`
+	Description     string   `json:"description,omitempty"`
+	PrivacyStatus   string   `json:"privacy_status,omitempty"`
+	DefaultLanguage string   `json:"default_language,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+}
+
+func (dto *PlaylistSettingsDTO) ToEntity() entities.PlaylistSettings {
+	return entities.PlaylistSettings{
+		Title:           dto.Title,
+		Description:     dto.Description,
+		PrivacyStatus:   dto.PrivacyStatus,
+		DefaultLanguage: dto.DefaultLanguage,
+		Tags:            dto.Tags,
+	}
+}
+
+func PlaylistSettingsFromEntity(entity entities.PlaylistSettings) PlaylistSettingsDTO {
+	return PlaylistSettingsDTO{
+		Title:           entity.Title,
+		Description:     entity.Description,
+		PrivacyStatus:   entity.PrivacyStatus,
+		DefaultLanguage: entity.DefaultLanguage,
+		Tags:            entity.Tags,
+	}
+}
@@ -0,0 +1,59 @@
+package DTOs
+
+import (
+	"project/internal/core/entities"
+	"time"
+)
+
+// CacheSchemaVersion é a versão dos registros gravados hoje no Redis. Registros sem o
+// campo "v" são da versão 1, anterior ao versionamento.
+const CacheSchemaVersion = 2
+
+// VideoCacheDTO é o registro de video:<id>. Na versão 1 o registro era um VideoRedisDTO,
+// com o canal guardado em "artist".
+type VideoCacheDTO struct {
+	Version     int           `json:"v"`
+	Id          string        `json:"id"`
+	Title       string        `json:"title"`
+	ChannelId   string        `json:"channel_id"`
+	Language    string        `json:"language"`
+	PublishedAt time.Time     `json:"published_at"`
+	Duration    time.Duration `json:"duration"`
+}
+
+func (dto *VideoCacheDTO) ToEntity() entities.VideoInterface {
+	return entities.NewVideo(dto.Id, dto.Title, dto.ChannelId, dto.Language, dto.PublishedAt, dto.Duration)
+}
+
+func VideoCacheFromEntity(entity entities.VideoInterface) VideoCacheDTO {
+	return VideoCacheDTO{
+		Version:     CacheSchemaVersion,
+		Id:          entity.Id(),
+		Title:       entity.Title(),
+		ChannelId:   entity.ChannelId(),
+		Language:    entity.Language(),
+		PublishedAt: entity.PublishedAt(),
+		Duration:    entity.Duration(),
+	}
+}
+
+// PlaylistCacheDTO é o resumo de uma playlist no hash playlists:<userId>. A versão 1
+// tinha os mesmos campos, sem "v".
+type PlaylistCacheDTO struct {
+	Version int `json:"v"`
+	PlaylistRedisDTO
+}
+
+func PlaylistCacheFromEntity(entity entities.PlaylistInterface) PlaylistCacheDTO {
+	return PlaylistCacheDTO{
+		Version:          CacheSchemaVersion,
+		PlaylistRedisDTO: PlaylistSummaryFromEntity(entity),
+	}
+}
+
+// PlaylistVideosCacheDTO é o registro de playlist_videos:<userId>:<playlistId>. Na versão 1 era
+// um array: primeiro com os vídeos completos e depois só com os ids.
+type PlaylistVideosCacheDTO struct {
+	Version  int      `json:"v"`
+	VideoIds []string `json:"video_ids"`
+}
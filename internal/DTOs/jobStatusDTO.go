@@ -0,0 +1,24 @@
+package DTOs
+
+import "time"
+
+// JobStatusDTO é o estado de um job assíncrono, lido por qualquer réplica em GET /jobs/{id}.
+// A chave de idempotência é única por usuário, para que um pedido repetido aponte para o job original.
+type JobStatusDTO struct {
+	ID             string    `gorm:"primaryKey;column:id"`
+	UserId         string    `gorm:"column:user_id;not null;index;uniqueIndex:idx_job_statuses_idempotency,priority:1,where:idempotency_key <> ''"`
+	Action         string    `gorm:"column:action;not null"`
+	PlaylistId     string    `gorm:"column:playlist_id"`
+	IdempotencyKey string    `gorm:"column:idempotency_key;not null;default:'';uniqueIndex:idx_job_statuses_idempotency,priority:2"`
+	State          string    `gorm:"column:state;not null"`
+	Total          int       `gorm:"column:total;not null;default:0"`
+	Done           int       `gorm:"column:done;not null;default:0"`
+	Failed         int       `gorm:"column:failed;not null;default:0"`
+	Errors         []string  `gorm:"column:errors;type:jsonb;serializer:json;not null;default:'[]'"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (dto *JobStatusDTO) TableName() string {
+	return "job_statuses"
+}
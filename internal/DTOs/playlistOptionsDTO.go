@@ -0,0 +1,37 @@
+package DTOs
+
+import "project/internal/core/entities"
+
+type PlaylistOptionsDTO struct {
+	PrivacyStatus       string   `json:"privacy_status,omitempty"`
+	TitleTemplate       string   `json:"title_template,omitempty"`
+	DescriptionTemplate string   `json:"description_template,omitempty"`
+	DefaultLanguage     string   `json:"default_language,omitempty"`
+	Tags                []string `json:"tags,omitempty"`
+	ReplaceOriginal     bool     `json:"replace_original,omitempty"`
+	RenameToOriginal    bool     `json:"rename_to_original,omitempty"`
+}
+
+func (dto *PlaylistOptionsDTO) ToEntity() entities.PlaylistOptions {
+	return entities.PlaylistOptions{
+		PrivacyStatus:       dto.PrivacyStatus,
+		TitleTemplate:       dto.TitleTemplate,
+		DescriptionTemplate: dto.DescriptionTemplate,
+		DefaultLanguage:     dto.DefaultLanguage,
+		Tags:                dto.Tags,
+		ReplaceOriginal:     dto.ReplaceOriginal,
+		RenameToOriginal:    dto.RenameToOriginal,
+	}
+}
+
+func PlaylistOptionsFromEntity(entity entities.PlaylistOptions) PlaylistOptionsDTO {
+	return PlaylistOptionsDTO{
+		PrivacyStatus:       entity.PrivacyStatus,
+		TitleTemplate:       entity.TitleTemplate,
+		DescriptionTemplate: entity.DescriptionTemplate,
+		DefaultLanguage:     entity.DefaultLanguage,
+		Tags:                entity.Tags,
+		ReplaceOriginal:     entity.ReplaceOriginal,
+		RenameToOriginal:    entity.RenameToOriginal,
+	}
+}
@@ -0,0 +1,7 @@
+package DTOs
+
+// PlaylistPageDTO é a resposta paginada de GET /playlists/all.
+type PlaylistPageDTO struct {
+	Items      []PlaylistRedisDTO `json:"items"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
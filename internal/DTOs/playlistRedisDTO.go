@@ -6,12 +6,17 @@ import (
 )
 
 type PlaylistRedisDTO struct {
-	Id          string          `json:"id"`
-	ChannelId   string          `json:"channelId"`
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	PublishedAt time.Time       `json:"publishedAt"`
-	Videos      []VideoRedisDTO `json:"videos"`
+	Id              string          `json:"id"`
+	ChannelId       string          `json:"channelId"`
+	Title           string          `json:"title"`
+	Description     string          `json:"description"`
+	PublishedAt     time.Time       `json:"publishedAt"`
+	PrivacyStatus   string          `json:"privacyStatus,omitempty"`
+	DefaultLanguage string          `json:"defaultLanguage,omitempty"`
+	Tags            []string        `json:"tags,omitempty"`
+	ThumbnailUrl    string          `json:"thumbnailUrl,omitempty"`
+	ItemCount       int             `json:"itemCount"`
+	Videos          []VideoRedisDTO `json:"videos,omitempty"`
 }
 
 func (dto *PlaylistRedisDTO) ToEntity() entities.PlaylistInterface {
@@ -22,7 +27,7 @@ func (dto *PlaylistRedisDTO) ToEntity() entities.PlaylistInterface {
 		videos[i] = video.ToEntity()
 	}
 
-	return entities.NewPlaylist(
+	playlist := entities.NewPlaylist(
 		dto.Id,
 		dto.ChannelId,
 		dto.Title,
@@ -30,20 +35,44 @@ func (dto *PlaylistRedisDTO) ToEntity() entities.PlaylistInterface {
 		dto.PublishedAt,
 		videos,
 	)
+	playlist.SetPrivacyStatus(dto.PrivacyStatus)
+	playlist.SetDefaultLanguage(dto.DefaultLanguage)
+	playlist.SetTags(dto.Tags)
+	playlist.SetThumbnailUrl(dto.ThumbnailUrl)
+	playlist.SetItemCount(dto.ItemCount)
+
+	return playlist
 }
 
 func PlaylistFromEntity(entity entities.PlaylistInterface) PlaylistRedisDTO {
-	videos := make([]VideoRedisDTO, len(entity.Videos()))
-	for i, video := range entity.Videos() {
-		videos[i] = VideoFromEntity(video)
-	}
+	videos := VideosFromEntities(entity.Videos())
 
 	return PlaylistRedisDTO{
-		Id:          entity.Id(),
-		ChannelId:   entity.ChannelId(),
-		Title:       entity.Title(),
-		Description: entity.Description(),
-		PublishedAt: entity.PublishedAt(),
-		Videos:      videos,
+		Id:              entity.Id(),
+		ChannelId:       entity.ChannelId(),
+		Title:           entity.Title(),
+		Description:     entity.Description(),
+		PublishedAt:     entity.PublishedAt(),
+		PrivacyStatus:   entity.PrivacyStatus(),
+		DefaultLanguage: entity.DefaultLanguage(),
+		Tags:            entity.Tags(),
+		ThumbnailUrl:    entity.ThumbnailUrl(),
+		ItemCount:       entity.ItemCount(),
+		Videos:          videos,
+	}
+}
+
+// PlaylistSummaryFromEntity monta o DTO da listagem, sem os vídeos.
+func PlaylistSummaryFromEntity(entity entities.PlaylistInterface) PlaylistRedisDTO {
+	dto := PlaylistFromEntity(entity)
+	dto.Videos = nil
+	return dto
+}
+
+func VideosFromEntities(videos []entities.VideoInterface) []VideoRedisDTO {
+	dtos := make([]VideoRedisDTO, len(videos))
+	for i, video := range videos {
+		dtos[i] = VideoFromEntity(video)
 	}
+	return dtos
 }
@@ -0,0 +1,19 @@
+package DTOs
+
+import "time"
+
+// QueuedJobDTO é uma mensagem da fila no Postgres. Mensagens com DeadAt preenchido estão na
+// fila de mensagens mortas e não são mais entregues.
+type QueuedJobDTO struct {
+	ID        string     `gorm:"primaryKey;column:id"`
+	Queue     string     `gorm:"column:queue;not null;index:idx_queued_jobs_due,priority:1"`
+	RunAt     time.Time  `gorm:"column:run_at;not null;index:idx_queued_jobs_due,priority:2"`
+	Body      []byte     `gorm:"column:body;not null"`
+	DeadAt    *time.Time `gorm:"column:dead_at"`
+	Reason    string     `gorm:"column:reason"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+}
+
+func (dto *QueuedJobDTO) TableName() string {
+	return "queued_jobs"
+}
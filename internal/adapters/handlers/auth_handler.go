@@ -9,15 +9,17 @@ import (
 	"net/http"
 	"project/internal/core/entities"
 	"project/internal/core/services"
+	"project/internal/infrastructure/auth"
 	"project/internal/infrastructure/logging"
 	"project/internal/infrastructure/repository"
 	"project/internal/infrastructure/sessions"
 )
 
 type handler struct {
-	auth  services.AuthService
-	store sessions.SessionManager
-	repo  repository.UserRepositoryInterface
+	auth    services.AuthService
+	store   sessions.SessionManager
+	repo    repository.UserRepositoryInterface
+	clients auth.YoutubeClientProviderInterface
 }
 
 type AuthHandler interface {
@@ -26,8 +28,8 @@ type AuthHandler interface {
 	OAuthLogout(w http.ResponseWriter, r *http.Request)
 }
 
-func NewHandler(auth services.AuthService, store sessions.SessionManager, repo repository.UserRepositoryInterface) AuthHandler {
-	return &handler{auth: auth, store: store, repo: repo}
+func NewHandler(authService services.AuthService, store sessions.SessionManager, repo repository.UserRepositoryInterface, clients auth.YoutubeClientProviderInterface) AuthHandler {
+	return &handler{auth: authService, store: store, repo: repo, clients: clients}
 }
 
 func (h *handler) OAuthLogin(w http.ResponseWriter, r *http.Request) {
@@ -52,7 +54,7 @@ func (h *handler) OAuthCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	foundUser, err := h.repo.GetUserByID(user.UserID)
+	foundUser, err := h.repo.GetUserByID(r.Context(), user.UserID)
 	if err != nil {
 		logging.Error("OAuthCallback - repo.GetUserByID", zap.String("Find error: ", err.Error()))
 		return
@@ -60,7 +62,7 @@ func (h *handler) OAuthCallback(w http.ResponseWriter, r *http.Request) {
 
 	if foundUser == nil {
 		logging.Info("OAuthCallback - user not found", zap.String("Creating new user: ", user.UserID))
-		err := h.repo.CreateUser(entities.NewUser(
+		err := h.repo.CreateUser(r.Context(), entities.NewUser(
 			user.UserID,
 			user.Name,
 			user.Email,
@@ -82,10 +84,15 @@ func (h *handler) OAuthCallback(w http.ResponseWriter, r *http.Request) {
 
 func (h *handler) OAuthLogout(w http.ResponseWriter, r *http.Request) {
 	logging.Info("OAuth Logout", zap.String("Init Logout process", ""))
+	// O id sai da sessão, que é destruída a seguir.
+	userId := h.store.GetUserId(r)
 	err := h.auth.LogoutHandler(w, r)
 	if err != nil {
 		return
 	}
+	if userId != "" {
+		h.clients.Forget(userId)
+	}
 
 	err = h.store.DestroySession(r, w)
 	if err != nil {
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"net/http"
+	"project/internal/infrastructure/cache"
+	"project/internal/infrastructure/logging"
+	"project/internal/infrastructure/repository"
+)
+
+type cacheAdminHandler struct {
+	Repo  repository.PlaylistRepositoryRedisInterface
+	Stats cache.MetricsInterface
+}
+
+type CacheAdminHandlerInterface interface {
+	Metrics(w http.ResponseWriter, r *http.Request)
+	UserCache(w http.ResponseWriter, r *http.Request)
+	EvictUser(w http.ResponseWriter, r *http.Request)
+}
+
+func NewCacheAdminHandler(repo repository.PlaylistRepositoryRedisInterface, metrics cache.MetricsInterface) CacheAdminHandlerInterface {
+	return &cacheAdminHandler{
+		Repo:  repo,
+		Stats: metrics,
+	}
+}
+
+// Metrics responde GET /admin/cache/metrics com os contadores de cada operação do cache e do repositório.
+func (h *cacheAdminHandler) Metrics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.Stats.Snapshot())
+}
+
+// UserCache responde GET /admin/cache/users/{userId} com as playlists em cache do usuário, idade e tamanho.
+func (h *cacheAdminHandler) UserCache(w http.ResponseWriter, r *http.Request) {
+	userId := mux.Vars(r)["userId"]
+
+	info, err := h.Repo.DescribeUserCache(r.Context(), userId)
+	if err != nil {
+		logging.Error("UserCache - cache_admin_handler", zap.String("user_id", userId), zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// EvictUser responde DELETE /admin/cache/users/{userId} removendo todo o cache do usuário.
+func (h *cacheAdminHandler) EvictUser(w http.ResponseWriter, r *http.Request) {
+	userId := mux.Vars(r)["userId"]
+
+	if err := h.Repo.EvictUser(r.Context(), userId); err != nil {
+		logging.Error("EvictUser - cache_admin_handler", zap.String("user_id", userId), zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	logging.Info("Cache do usuário removido", zap.String("user_id", userId))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"net/http"
+	"project/internal/infrastructure/logging"
+	"project/internal/infrastructure/messaging"
+)
+
+type deadLetterHandler struct {
+	Queue messaging.DeadLetterQueueInterface
+}
+
+type DeadLetterHandlerInterface interface {
+	List(w http.ResponseWriter, r *http.Request)
+	Replay(w http.ResponseWriter, r *http.Request)
+	ReplayAll(w http.ResponseWriter, r *http.Request)
+	Discard(w http.ResponseWriter, r *http.Request)
+	DiscardAll(w http.ResponseWriter, r *http.Request)
+}
+
+func NewDeadLetterHandler(queue messaging.DeadLetterQueueInterface) DeadLetterHandlerInterface {
+	return &deadLetterHandler{Queue: queue}
+}
+
+// List responde GET /admin/dead-letters com as ações mortas, o último erro e quando desistimos delas.
+func (h *deadLetterHandler) List(w http.ResponseWriter, r *http.Request) {
+	letters, err := h.Queue.List(r.Context())
+	if err != nil {
+		logging.Error("List - dead_letter_handler", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if letters == nil {
+		letters = []messaging.DeadLetter{}
+	}
+	writeJSON(w, http.StatusOK, letters)
+}
+
+// Replay responde POST /admin/dead-letters/{id}/replay devolvendo a ação à fila principal.
+func (h *deadLetterHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	h.writeResult(w, "Replay", id, h.Queue.Replay(r.Context(), id))
+}
+
+// ReplayAll responde POST /admin/dead-letters/replay devolvendo todas as ações à fila principal.
+func (h *deadLetterHandler) ReplayAll(w http.ResponseWriter, r *http.Request) {
+	n, err := h.Queue.ReplayAll(r.Context())
+	h.writeCount(w, "ReplayAll", "replayed", n, err)
+}
+
+// Discard responde DELETE /admin/dead-letters/{id} removendo a ação de vez.
+func (h *deadLetterHandler) Discard(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	h.writeResult(w, "Discard", id, h.Queue.Discard(r.Context(), id))
+}
+
+// DiscardAll responde DELETE /admin/dead-letters esvaziando a fila de mensagens mortas.
+func (h *deadLetterHandler) DiscardAll(w http.ResponseWriter, r *http.Request) {
+	n, err := h.Queue.DiscardAll(r.Context())
+	h.writeCount(w, "DiscardAll", "discarded", n, err)
+}
+
+func (h *deadLetterHandler) writeResult(w http.ResponseWriter, operation, id string, err error) {
+	switch {
+	case err == nil:
+		logging.Info("Mensagem morta tratada", zap.String("operation", operation), zap.String("id", id))
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, messaging.ErrDeadLetterNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		logging.Error(operation+" - dead_letter_handler", zap.String("id", id), zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// writeCount informa quantas mensagens foram tratadas mesmo quando a varredura parou no meio.
+func (h *deadLetterHandler) writeCount(w http.ResponseWriter, operation, field string, n int, err error) {
+	if err != nil {
+		logging.Error(operation+" - dead_letter_handler", zap.Int(field, n), zap.Error(err))
+		writeJSON(w, http.StatusInternalServerError, map[string]any{field: n, "error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{field: n})
+}
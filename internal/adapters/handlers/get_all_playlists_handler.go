@@ -2,31 +2,34 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"go.uber.org/zap"
-	"golang.org/x/oauth2"
 	"net/http"
 	"project/internal/DTOs"
-	"project/internal/core/services"
+	"project/internal/core/entities"
+	"project/internal/core/usecases"
 	"project/internal/infrastructure/logging"
+	"project/internal/infrastructure/quota"
 	"project/internal/infrastructure/repository"
 	"project/internal/infrastructure/sessions"
+	"strconv"
 )
 
 type getAllPlaylistsHandler struct {
-	PlaylistService services.YoutubePlaylistService
-	Session         sessions.SessionManager
-	repo            repository.UserRepositoryInterface
+	UseCase usecases.GetAllPlaylistsUseCase
+	Session sessions.SessionManager
+	repo    repository.UserRepositoryInterface
 }
 
 type GetAllPlaylistsHandlerInterface interface {
 	GetAllPlaylists(w http.ResponseWriter, r *http.Request)
 }
 
-func NewGetAllPlaylistsHandler(playlistService services.YoutubePlaylistService, session sessions.SessionManager, UserRepositoryInterface repository.UserRepositoryInterface) GetAllPlaylistsHandlerInterface {
+func NewGetAllPlaylistsHandler(useCase usecases.GetAllPlaylistsUseCase, session sessions.SessionManager, UserRepositoryInterface repository.UserRepositoryInterface) GetAllPlaylistsHandlerInterface {
 	return &getAllPlaylistsHandler{
-		PlaylistService: playlistService,
-		Session:         session,
-		repo:            UserRepositoryInterface,
+		UseCase: useCase,
+		Session: session,
+		repo:    UserRepositoryInterface,
 	}
 }
 
@@ -40,30 +43,75 @@ func (h *getAllPlaylistsHandler) GetAllPlaylists(w http.ResponseWriter, r *http.
 
 	logging.Info("GetAllPlaylists - Get all playlists", zap.String("userId", userId))
 
-	user, err := h.repo.GetUserByID(userId)
-	if err != nil {
-		logging.Error("GetAllPlaylists - get_all_playlists_handler", zap.String("getting user error", err.Error()))
+	user, err := h.repo.GetUserByID(r.Context(), userId)
+	if err != nil || user == nil {
+		logging.Error("GetAllPlaylists - get_all_playlists_handler", zap.String("user_id", userId), zap.Error(err))
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	oAuthToken := &oauth2.Token{AccessToken: user.Token()}
+	// Sem cursor nem limit a resposta continua sendo a lista completa.
+	query := r.URL.Query()
+	paginated := query.Has("cursor") || query.Has("limit")
+
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			http.Error(w, "limit deve ser um número inteiro positivo", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// refresh=true ignora o cache e busca a listagem de novo no YouTube.
+	refresh := query.Get("refresh") == "true"
 
-	playlists, err := h.PlaylistService.GetAllPlaylists(r.Context(), oAuthToken, r)
+	var page usecases.PlaylistPage
+	if paginated {
+		page, err = h.UseCase.ExecutePage(r.Context(), user.Id(), query.Get("cursor"), limit, refresh)
+	} else {
+		page.Playlists, err = h.UseCase.Execute(r.Context(), user.Id(), refresh)
+	}
+	// include=videos devolve as playlists completas, com os vídeos buscados em paralelo.
+	includeVideos := query.Get("include") == "videos"
+	if err == nil && includeVideos {
+		page.Playlists, err = h.UseCase.WithVideos(r.Context(), user.Id(), page.Playlists, refresh)
+	}
 	if err != nil {
 		logging.Error("GetAllPlaylists", zap.String("error", err.Error()))
+		if errors.Is(err, usecases.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, quota.ErrBudgetExceeded) {
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	playlistsDTO := make([]DTOs.PlaylistRedisDTO, len(playlists))
-	for i, playlist := range playlists {
-		playlistsDTO[i] = DTOs.PlaylistFromEntity(playlist)
+	playlistsDTO := playlistsToDTO(page.Playlists, includeVideos)
+
+	var response any = playlistsDTO
+	if paginated {
+		response = DTOs.PlaylistPageDTO{Items: playlistsDTO, NextCursor: page.NextCursor}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(playlistsDTO); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
 }
+
+func playlistsToDTO(playlists []entities.PlaylistInterface, includeVideos bool) []DTOs.PlaylistRedisDTO {
+	playlistsDTO := make([]DTOs.PlaylistRedisDTO, len(playlists))
+	for i, playlist := range playlists {
+		if includeVideos {
+			playlistsDTO[i] = DTOs.PlaylistFromEntity(playlist)
+			continue
+		}
+		playlistsDTO[i] = DTOs.PlaylistSummaryFromEntity(playlist)
+	}
+	return playlistsDTO
+}
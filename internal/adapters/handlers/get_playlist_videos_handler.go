@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"net/http"
+	"project/internal/DTOs"
+	"project/internal/core/usecases"
+	"project/internal/infrastructure/circuitbreaker"
+	"project/internal/infrastructure/logging"
+	"project/internal/infrastructure/quota"
+	"project/internal/infrastructure/sessions"
+)
+
+type getPlaylistVideosHandler struct {
+	UseCase usecases.GetPlaylistVideosUseCaseInterface
+	Session sessions.SessionManager
+}
+
+type GetPlaylistVideosHandlerInterface interface {
+	GetPlaylistVideos(w http.ResponseWriter, r *http.Request)
+}
+
+func NewGetPlaylistVideosHandler(uc usecases.GetPlaylistVideosUseCaseInterface, session sessions.SessionManager) GetPlaylistVideosHandlerInterface {
+	return &getPlaylistVideosHandler{
+		UseCase: uc,
+		Session: session,
+	}
+}
+
+// GetPlaylistVideos responde GET /playlists/{id}/videos com os vídeos da playlist;
+// refresh=true ignora o cache. O usuário vem só da sessão: é com ele que o cache é lido.
+func (h *getPlaylistVideosHandler) GetPlaylistVideos(w http.ResponseWriter, r *http.Request) {
+	playlistId := mux.Vars(r)["id"]
+	userId := h.Session.GetUserId(r)
+	if userId == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	videos, err := h.UseCase.Execute(r.Context(), playlistId, userId, r.URL.Query().Get("refresh") == "true")
+	if err != nil {
+		logging.Error("GetPlaylistVideos - get_playlist_videos_handler", zap.String("playlist_id", playlistId), zap.Error(err))
+		if errors.Is(err, quota.ErrBudgetExceeded) {
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
+		if errors.Is(err, circuitbreaker.ErrOpen) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(DTOs.VideosFromEntities(videos)); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
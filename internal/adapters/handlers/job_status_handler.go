@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"net/http"
+	"project/internal/infrastructure/jobs"
+	"project/internal/infrastructure/logging"
+	"project/internal/infrastructure/sessions"
+)
+
+type jobStatusHandler struct {
+	Jobs    jobs.StoreInterface
+	Session sessions.SessionManager
+}
+
+type JobStatusHandlerInterface interface {
+	GetJob(w http.ResponseWriter, r *http.Request)
+}
+
+func NewJobStatusHandler(store jobs.StoreInterface, session sessions.SessionManager) JobStatusHandlerInterface {
+	return &jobStatusHandler{
+		Jobs:    store,
+		Session: session,
+	}
+}
+
+// GetJob responde GET /jobs/{id} com o estado, o progresso e os erros do job. Jobs de outro
+// usuário respondem 404, como se não existissem.
+func (h *jobStatusHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	status, err := h.Jobs.Get(r.Context(), id)
+	if errors.Is(err, jobs.ErrJobNotFound) || (err == nil && status.UserId != h.Session.GetUserId(r)) {
+		http.Error(w, jobs.ErrJobNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logging.Error("GetJob - job_status_handler", zap.String("job_id", id), zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
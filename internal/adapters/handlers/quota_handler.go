@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"net/http"
+	"project/internal/infrastructure/logging"
+	"project/internal/infrastructure/quota"
+	"project/internal/infrastructure/sessions"
+	"strconv"
+)
+
+type quotaHandler struct {
+	Ledger  quota.LedgerInterface
+	Session sessions.SessionManager
+}
+
+type QuotaHandlerInterface interface {
+	GetUsage(w http.ResponseWriter, r *http.Request)
+	Estimate(w http.ResponseWriter, r *http.Request)
+}
+
+func NewQuotaHandler(ledger quota.LedgerInterface, session sessions.SessionManager) QuotaHandlerInterface {
+	return &quotaHandler{
+		Ledger:  ledger,
+		Session: session,
+	}
+}
+
+func (h *quotaHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	userId := h.userId(r)
+
+	usage, err := h.Ledger.Usage(r.Context(), userId)
+	if err != nil {
+		logging.Error("GetUsage - quota_handler", zap.String("user_id", userId), zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(usage); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Estimate responde quanto uma operação vai custar, ex.: /quota/estimate?operation=reorder&items=120.
+// Para merge e split, "playlists" indica o número de playlists de origem ou de partes.
+func (h *quotaHandler) Estimate(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	items, err := strconv.Atoi(query.Get("items"))
+	if err != nil {
+		http.Error(w, "items deve ser um número inteiro", http.StatusBadRequest)
+		return
+	}
+
+	plan := quota.Plan{
+		Operation: query.Get("operation"),
+		Items:     items,
+		Rename:    query.Get("rename") == "true",
+	}
+	if value := query.Get("playlists"); value != "" {
+		if plan.Playlists, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "playlists deve ser um número inteiro", http.StatusBadRequest)
+			return
+		}
+	}
+
+	userId := h.userId(r)
+	estimate, err := quota.EstimateForUser(r.Context(), h.Ledger, userId, plan)
+	if err != nil {
+		if errors.Is(err, quota.ErrInvalidPlan) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		logging.Error("Estimate - quota_handler", zap.String("user_id", userId), zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(estimate); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func (h *quotaHandler) userId(r *http.Request) string {
+	userId := h.Session.GetUserId(r)
+	if userId == "" {
+		userId = r.Header.Get("X-User-Id")
+	}
+	return userId
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"go.uber.org/zap"
 	"net/http"
+	"project/internal/DTOs"
 	"project/internal/core/usecases"
 	"project/internal/infrastructure/logging"
 	"project/internal/infrastructure/sessions"
@@ -26,10 +28,14 @@ func NewPlaylistHandler(uc usecases.ReorderPlaylistUseCaseInterface, session ses
 }
 
 type ReorderPlaylistRequest struct {
-	PlaylistId string `json:"playlist_id"`
-	Criteria   string `json:"criteria"` // Ex.: "byName", "byPublishedAt", "byDuration"
+	PlaylistId string                  `json:"playlist_id"`
+	Criteria   string                  `json:"criteria"` // Ex.: "byTitle", "byPublishedAt", "byDuration"
+	Options    DTOs.PlaylistOptionsDTO `json:"options"`
 }
 
+// ReorderPlaylist enfileira a reordenação e responde 202 com o id do job; o andamento é
+// consultado em GET /jobs/{id}. Um pedido repetido com o mesmo Idempotency-Key recebe o
+// job original.
 func (h *reorderPlaylistHandler) ReorderPlaylist(w http.ResponseWriter, r *http.Request) {
 	var req ReorderPlaylistRequest
 	userId := h.Session.GetUserId(r)
@@ -42,14 +48,21 @@ func (h *reorderPlaylistHandler) ReorderPlaylist(w http.ResponseWriter, r *http.
 		http.Error(w, "playlist_id e criteria são obrigatórios", http.StatusBadRequest)
 		return
 	}
-	if err := h.ReorderUseCase.Execute(req.PlaylistId, req.Criteria, userId, r.Context()); err != nil {
-		logging.Error("Erro ao reordenar playlist - reorder_playlist_handler - ln46", zap.String("err", err.Error()))
+	jobId, err := h.ReorderUseCase.Execute(r.Context(), req.PlaylistId, req.Criteria, userId, req.Options.ToEntity())
+	if err != nil {
+		logging.Error("Erro ao enfileirar reordenação da playlist", zap.String("playlist_id", req.PlaylistId), zap.Error(err))
+		if errors.Is(err, usecases.ErrInvalidReorder) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	response := map[string]string{"message": "Playlist reordenada com sucesso"}
-	logging.Info("Reordenada com sucesso", zap.String("ID: "+req.PlaylistId, "Criteria: "+req.Criteria))
+	statusUrl := "/jobs/" + jobId
+	response := map[string]string{"job_id": jobId, "status_url": statusUrl}
+	logging.Info("Reordenação enfileirada", zap.String("playlist_id", req.PlaylistId), zap.String("criteria", req.Criteria), zap.String("job_id", jobId))
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Location", statusUrl)
+	w.WriteHeader(http.StatusAccepted)
 	json.NewEncoder(w).Encode(response)
 }
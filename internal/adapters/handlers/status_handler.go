@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"project/internal/infrastructure/circuitbreaker"
+)
+
+type statusHandler struct {
+	Breakers circuitbreaker.RegistryInterface
+}
+
+type StatusHandlerInterface interface {
+	CircuitBreakers(w http.ResponseWriter, r *http.Request)
+}
+
+func NewStatusHandler(breakers circuitbreaker.RegistryInterface) StatusHandlerInterface {
+	return &statusHandler{
+		Breakers: breakers,
+	}
+}
+
+// CircuitBreakers lista o estado do breaker de cada família de endpoints do YouTube.
+func (h *statusHandler) CircuitBreakers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(h.Breakers.Statuses()); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -6,12 +6,17 @@ import (
 )
 
 type playlist struct {
-	id          string
-	channelId   string
-	title       string
-	description string
-	publishedAt time.Time
-	videos      []VideoInterface
+	id              string
+	channelId       string
+	title           string
+	description     string
+	publishedAt     time.Time
+	videos          []VideoInterface
+	privacyStatus   string
+	defaultLanguage string
+	tags            []string
+	thumbnailUrl    string
+	itemCount       int
 }
 
 type PlaylistInterface interface {
@@ -21,6 +26,17 @@ type PlaylistInterface interface {
 	Description() string
 	PublishedAt() time.Time
 	Videos() []VideoInterface
+	PrivacyStatus() string
+	DefaultLanguage() string
+	Tags() []string
+	ThumbnailUrl() string
+	ItemCount() int
+	SetPrivacyStatus(privacyStatus string)
+	SetDefaultLanguage(language string)
+	SetTags(tags []string)
+	SetThumbnailUrl(url string)
+	SetItemCount(count int)
+	SetVideos(videos []VideoInterface)
 	SortByPublishedAt()
 	SortByTitle()
 	SortByDuration()
@@ -63,6 +79,54 @@ func (p *playlist) Videos() []VideoInterface {
 	return p.videos
 }
 
+func (p *playlist) PrivacyStatus() string {
+	return p.privacyStatus
+}
+
+func (p *playlist) DefaultLanguage() string {
+	return p.defaultLanguage
+}
+
+func (p *playlist) Tags() []string {
+	return p.tags
+}
+
+func (p *playlist) ThumbnailUrl() string {
+	return p.thumbnailUrl
+}
+
+// ItemCount é o total de vídeos informado pelo YouTube; sem ele, conta os vídeos carregados.
+func (p *playlist) ItemCount() int {
+	if p.itemCount > 0 {
+		return p.itemCount
+	}
+	return len(p.videos)
+}
+
+func (p *playlist) SetPrivacyStatus(privacyStatus string) {
+	p.privacyStatus = privacyStatus
+}
+
+func (p *playlist) SetDefaultLanguage(language string) {
+	p.defaultLanguage = language
+}
+
+func (p *playlist) SetTags(tags []string) {
+	p.tags = tags
+}
+
+func (p *playlist) SetThumbnailUrl(url string) {
+	p.thumbnailUrl = url
+}
+
+func (p *playlist) SetItemCount(count int) {
+	p.itemCount = count
+}
+
+func (p *playlist) SetVideos(videos []VideoInterface) {
+	p.videos = videos
+}
+
 func (p *playlist) SortByPublishedAt() {
 	sort.Slice(p.videos, func(i, j int) bool {
 		return p.videos[i].PublishedAt().Before(p.videos[j].PublishedAt())
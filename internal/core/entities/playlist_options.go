@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	PrivacyPrivate  = "private"
+	PrivacyUnlisted = "unlisted"
+	PrivacyPublic   = "public"
+
+	// O título padrão diferencia a cópia da original, que continuam lado a lado sem ReplaceOriginal.
+	DefaultTitleTemplate       = "{title} (ordenada)"
+	DefaultDescriptionTemplate = "{description}"
+)
+
+var (
+	ErrInvalidPrivacyStatus  = errors.New("invalid privacy status")
+	ErrRenameRequiresReplace = errors.New("rename_to_original requires replace_original")
+)
+
+// PlaylistOptions define como a playlist criada por uma reordenação deve ser configurada.
+// Campos vazios (inclusive Tags sem itens) herdam o valor da playlist de origem.
+//
+// Os templates de título e descrição aceitam os placeholders {title}, {description},
+// {criteria}, {date} (2006-01-02) e {datetime} (RFC3339).
+//
+// Com ReplaceOriginal a cópia ordenada substitui a playlist de origem, que só é
+// removida depois de confirmado que a cópia contém todos os seus itens.
+// RenameToOriginal renomeia a cópia para o título original após a troca.
+type PlaylistOptions struct {
+	PrivacyStatus       string
+	TitleTemplate       string
+	DescriptionTemplate string
+	DefaultLanguage     string
+	Tags                []string
+	ReplaceOriginal     bool
+	RenameToOriginal    bool
+}
+
+func (o PlaylistOptions) Validate() error {
+	switch o.PrivacyStatus {
+	case "", PrivacyPrivate, PrivacyUnlisted, PrivacyPublic:
+	default:
+		return ErrInvalidPrivacyStatus
+	}
+
+	if o.RenameToOriginal && !o.ReplaceOriginal {
+		return ErrRenameRequiresReplace
+	}
+
+	return nil
+}
+
+// PlaylistSettings são os valores finais, já resolvidos, usados para criar a nova playlist.
+type PlaylistSettings struct {
+	Title           string
+	Description     string
+	PrivacyStatus   string
+	DefaultLanguage string
+	Tags            []string
+}
+
+// Resolve preenche os valores herdados da playlist de origem e expande os templates.
+func (o PlaylistOptions) Resolve(source PlaylistInterface, criteria string, now time.Time) PlaylistSettings {
+	replacer := strings.NewReplacer(
+		"{title}", source.Title(),
+		"{description}", source.Description(),
+		"{criteria}", criteria,
+		"{date}", now.Format(time.DateOnly),
+		"{datetime}", now.Format(time.RFC3339),
+	)
+
+	titleTemplate := o.TitleTemplate
+	if titleTemplate == "" {
+		titleTemplate = DefaultTitleTemplate
+	}
+
+	descriptionTemplate := o.DescriptionTemplate
+	if descriptionTemplate == "" {
+		descriptionTemplate = DefaultDescriptionTemplate
+	}
+
+	settings := PlaylistSettings{
+		Title:           replacer.Replace(titleTemplate),
+		Description:     replacer.Replace(descriptionTemplate),
+		PrivacyStatus:   o.PrivacyStatus,
+		DefaultLanguage: o.DefaultLanguage,
+		Tags:            o.Tags,
+	}
+
+	if settings.PrivacyStatus == "" {
+		settings.PrivacyStatus = source.PrivacyStatus()
+	}
+	// Sem status conhecido na origem, nunca expõe a cópia por engano.
+	if settings.PrivacyStatus == "" {
+		settings.PrivacyStatus = PrivacyPrivate
+	}
+	if settings.DefaultLanguage == "" {
+		settings.DefaultLanguage = source.DefaultLanguage()
+	}
+	if len(settings.Tags) == 0 {
+		settings.Tags = source.Tags()
+	}
+
+	return settings
+}
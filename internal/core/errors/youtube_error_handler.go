@@ -1,5 +1,34 @@
 package errors
 
+import (
+	"context"
+	"errors"
+)
+
+// ErrDeferred marca erros cuja ação já foi enfileirada para reprocessamento,
+// evitando que ela seja publicada de novo quando o erro sobe pelas camadas.
+var ErrDeferred = errors.New("ação enfileirada para reprocessamento")
+
+// ErrPermanent marca falhas que se repetiriam a cada tentativa; o consumidor não as reprocessa.
+var ErrPermanent = errors.New("falha permanente")
+
+// Job descreve a operação que falhou com tudo o que o consumidor precisa para refazê-la.
+type Job struct {
+	Action     string
+	PlaylistId string
+	UserId     string
+	// Params são os parâmetros tipados da ação, gravados em JSON na mensagem.
+	Params any
+	// RequestId e IdempotencyKey vêm do contexto da requisição original quando vazios.
+	RequestId      string
+	IdempotencyKey string
+	// JobId é o job assíncrono do qual a ação faz parte, também herdado do contexto.
+	JobId string
+}
+
 type YouTubeErrorHandler interface {
-	HandleYouTubeError(err error, playlistId, action string) error
+	HandleYouTubeError(ctx context.Context, err error, job Job) error
+	// DeferJob enfileira job qualquer que seja err, inclusive transitório. Serve para o que
+	// sobra de uma operação que já não pode ser refeita do início.
+	DeferJob(ctx context.Context, err error, job Job) error
 }
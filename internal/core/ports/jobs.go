@@ -0,0 +1,16 @@
+// Package ports reúne as interfaces pelas quais o núcleo usa a infraestrutura, que as implementa.
+package ports
+
+import (
+	"context"
+
+	coreErrors "project/internal/core/errors"
+)
+
+// JobSchedulerInterface registra um job assíncrono e enfileira a ação que o executa.
+type JobSchedulerInterface interface {
+	// Schedule grava o job job.JobId, publica a ação e devolve o id do job. RequestId e
+	// IdempotencyKey vêm do contexto da requisição quando vazios. Se o usuário já agendou um
+	// job com a mesma IdempotencyKey, nada é publicado e o id devolvido é o desse job.
+	Schedule(ctx context.Context, job coreErrors.Job) (string, error)
+}
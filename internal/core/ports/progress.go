@@ -0,0 +1,12 @@
+package ports
+
+import "context"
+
+// ProgressReporterInterface registra o andamento do job em execução. O job vem de ctx;
+// sem job, as chamadas não fazem nada.
+type ProgressReporterInterface interface {
+	// Start anuncia quantos itens a execução vai processar.
+	Start(ctx context.Context, total int)
+	// Step conta o item como processado, ou como falha quando err não é nil.
+	Step(ctx context.Context, item string, err error)
+}
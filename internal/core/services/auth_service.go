@@ -17,7 +17,7 @@ type AuthService interface {
 	BeginAuthHandler(w http.ResponseWriter, r *http.Request)
 	CompleteUserAuth(w http.ResponseWriter, r *http.Request) (goth.User, error)
 	LogoutHandler(w http.ResponseWriter, r *http.Request) error
-	RefreshAccessToken(refreshToken string) (string, time.Time, error)
+	RefreshAccessToken(ctx context.Context, refreshToken string) (string, time.Time, error)
 }
 
 type GothAuthService struct{}
@@ -38,7 +38,7 @@ func (g *GothAuthService) LogoutHandler(w http.ResponseWriter, r *http.Request)
 	return gothic.Logout(w, r)
 }
 
-func (g *GothAuthService) RefreshAccessToken(refreshToken string) (string, time.Time, error) {
+func (g *GothAuthService) RefreshAccessToken(ctx context.Context, refreshToken string) (string, time.Time, error) {
 	configure := oauth2.Config{
 		ClientID:     config.EnvConfigs.ClientID,
 		ClientSecret: config.EnvConfigs.SecretKey,
@@ -49,7 +49,7 @@ func (g *GothAuthService) RefreshAccessToken(refreshToken string) (string, time.
 		RefreshToken: refreshToken,
 	}
 
-	newToken, err := configure.TokenSource(context.Background(), token).Token()
+	newToken, err := configure.TokenSource(ctx, token).Token()
 	if err != nil {
 		logging.Info("refreshAccessToken - auth_service - L54", zap.String("refresh token error", err.Error()))
 		return "", time.Time{}, nil
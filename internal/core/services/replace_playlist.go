@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"project/internal/DTOs"
+	"project/internal/core/entities"
+	coreErrors "project/internal/core/errors"
+	"project/internal/infrastructure/logging"
+	"project/internal/infrastructure/quota"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// ErrIncompleteCopy é permanente: refazer a cópia gastaria a mesma cota para chegar ao mesmo resultado.
+var ErrIncompleteCopy = fmt.Errorf("%w: a cópia da playlist não contém todos os itens da original", coreErrors.ErrPermanent)
+
+// replaceOriginal troca a playlist de origem pela cópia ordenada. A original só é removida
+// depois de verificado que todos os vídeos copiáveis foram inseridos e estão na cópia;
+// qualquer falha antes disso remove a cópia parcial e mantém a original intacta.
+//
+// Vídeos removidos ou privados não voltam em GetVideosDetails, então nunca fazem parte de
+// source.Videos() nem da cópia, e não contam como ausentes.
+//
+// Depois que a original é removida não há volta: o restante roda mesmo que ctx seja cancelado,
+// e uma falha ao renomear só enfileira a renomeação, sem fazer a reordenação falhar.
+func (s *youtubePlaylistService) replaceOriginal(ctx context.Context, service *youtube.Service, source entities.PlaylistInterface, copyId string, inserted []string, settings entities.PlaylistSettings, options entities.PlaylistOptions, userId string) error {
+	if skipped := len(source.Videos()) - len(inserted); skipped > 0 {
+		s.discardCopy(ctx, service, copyId)
+		return fmt.Errorf("%w: %d vídeos não foram inseridos", ErrIncompleteCopy, skipped)
+	}
+
+	if err := s.verifyCopy(ctx, service, copyId, inserted); err != nil {
+		s.discardCopy(ctx, service, copyId)
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		s.discardCopy(ctx, service, copyId)
+		return err
+	}
+
+	if err := s.deletePlaylist(ctx, service, userId, source.Id()); err != nil {
+		s.discardCopy(ctx, service, copyId)
+		return fmt.Errorf("erro ao remover a playlist original: %w", err)
+	}
+
+	if options.RenameToOriginal {
+		settings.Title = source.Title()
+		// A original já foi removida: a partir daqui a cópia é a única versão e não pode ser descartada.
+		if err := s.updatePlaylistSnippet(context.WithoutCancel(ctx), service, copyId, settings); err != nil {
+			logging.Error("Erro ao renomear a cópia da playlist", zap.String("playlist_id", copyId), zap.Error(err))
+			// A troca já aconteceu e a reordenação termina com sucesso; o que fica pendente é só renomear a cópia.
+			if err := s.errorHandler.DeferJob(context.WithoutCancel(ctx), err, updatePlaylistJob(copyId, settings, userId)); !errors.Is(err, coreErrors.ErrDeferred) {
+				logging.Error("Não foi possível enfileirar a renomeação da cópia", zap.String("playlist_id", copyId), zap.Error(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyCopy confere se cada vídeo inserido aparece na cópia, respeitando duplicados.
+func (s *youtubePlaylistService) verifyCopy(ctx context.Context, service *youtube.Service, copyId string, inserted []string) error {
+	copyIds, err := s.listPlaylistVideoIds(ctx, service, copyId)
+	if err != nil {
+		return err
+	}
+
+	remaining := make(map[string]int, len(copyIds))
+	for _, id := range copyIds {
+		remaining[id]++
+	}
+
+	var missing []string
+	for _, id := range inserted {
+		if remaining[id] == 0 {
+			missing = append(missing, id)
+			continue
+		}
+		remaining[id]--
+	}
+
+	if len(missing) > 0 {
+		logging.Error("Cópia da playlist incompleta", zap.String("copy_id", copyId), zap.Strings("missing", missing))
+		return fmt.Errorf("%w: %d vídeos ausentes", ErrIncompleteCopy, len(missing))
+	}
+
+	return nil
+}
+
+func (s *youtubePlaylistService) listPlaylistVideoIds(ctx context.Context, service *youtube.Service, playlistId string) ([]string, error) {
+	var ids []string
+	pageToken := ""
+	for {
+		pageIds, nextPageToken, err := s.getPlaylistVideoIds(ctx, service, playlistId, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, pageIds...)
+
+		if nextPageToken == "" {
+			return ids, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// discardCopy remove uma cópia parcial. Falhas são apenas registradas para não mascarar o erro original.
+// A limpeza também é feita quando a operação foi cancelada, por isso ignora o cancelamento de ctx.
+func (s *youtubePlaylistService) discardCopy(ctx context.Context, service *youtube.Service, copyId string) {
+	ctx = context.WithoutCancel(ctx)
+	err := s.call(ctx, "delete_playlist", func() error {
+		return service.Playlists.Delete(copyId).Context(ctx).Do()
+	})
+	if err != nil {
+		logging.Error("Erro ao remover cópia parcial da playlist", zap.String("playlist_id", copyId), zap.Error(err))
+		return
+	}
+	logging.Info("Cópia parcial da playlist removida", zap.String("playlist_id", copyId))
+}
+
+// UpdatePlaylist troca título, descrição, idioma e tags da playlist; a listagem do usuário em cache deixa de valer.
+func (s *youtubePlaylistService) UpdatePlaylist(ctx context.Context, playlistId string, settings entities.PlaylistSettings, userId string) error {
+	if err := s.checkBudget(ctx, updatePlaylistJob(playlistId, settings, userId), quota.WriteCost); err != nil {
+		return err
+	}
+
+	ytService, err := s.getYoutubeService(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.updatePlaylistSnippet(ctx, ytService, playlistId, settings); err != nil {
+		return s.errorHandler.HandleYouTubeError(ctx, err, updatePlaylistJob(playlistId, settings, userId))
+	}
+	if err := s.repo.InvalidatePlaylists(context.WithoutCancel(ctx), userId); err != nil {
+		logging.Info("Playlist atualizada no YouTube, mas o cache não foi invalidado", zap.String("playlist_id", playlistId), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *youtubePlaylistService) updatePlaylistSnippet(ctx context.Context, service *youtube.Service, playlistId string, settings entities.PlaylistSettings) error {
+	call := service.Playlists.Update([]string{"snippet"}, &youtube.Playlist{
+		Id: playlistId,
+		Snippet: &youtube.PlaylistSnippet{
+			Title:           settings.Title,
+			Description:     settings.Description,
+			DefaultLanguage: settings.DefaultLanguage,
+			Tags:            settings.Tags,
+		},
+	})
+	err := s.call(ctx, "update_playlist", func() error {
+		_, err := call.Context(ctx).Do()
+		return err
+	})
+	return err
+}
+
+func updatePlaylistJob(playlistId string, settings entities.PlaylistSettings, userId string) coreErrors.Job {
+	return coreErrors.Job{
+		Action:     "update_playlist",
+		PlaylistId: playlistId,
+		UserId:     userId,
+		Params:     DTOs.UpdatePlaylistParamsDTO{Settings: DTOs.PlaylistSettingsFromEntity(settings)},
+	}
+}
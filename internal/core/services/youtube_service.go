@@ -5,141 +5,355 @@ import (
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
-	"log"
 	"net/http"
+	"project/internal/infrastructure/auth"
+	"project/internal/infrastructure/circuitbreaker"
 	"project/internal/infrastructure/logging"
-	"project/internal/infrastructure/sessions"
+	"project/internal/infrastructure/quota"
+	"project/internal/infrastructure/retry"
+	"project/internal/infrastructure/workerpool"
+	"sync"
 	"time"
 
 	"github.com/sosodev/duration"
-	"golang.org/x/oauth2"
-	"google.golang.org/api/option"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/youtube/v3"
+	"project/internal/DTOs"
 	"project/internal/core/entities"
 	coreErrors "project/internal/core/errors"
+	"project/internal/core/ports"
 	"project/internal/infrastructure/repository"
 )
 
+// maxVideosPerRequest é o limite de ids aceito por Videos.List e PlaylistItems.List.
+const maxVideosPerRequest = 50
+
+// DefaultFreshFor é por quanto tempo uma leitura do cache é servida sem revalidação.
+const DefaultFreshFor = 15 * time.Minute
+
+// refreshTimeout limita uma revalidação em segundo plano, que não tem um cliente esperando.
+const refreshTimeout = 2 * time.Minute
+
 // YoutubePlaylistService define as operações para gerenciar playlists do YouTube.
+// Todas as operações usam o cliente do YouTube do próprio usuário (userId) e param
+// assim que ctx é cancelado ou o prazo dele expira. Nas leituras, refresh ignora o cache
+// e busca direto no YouTube.
 type YoutubePlaylistService interface {
-	GetAllPlaylists(ctx context.Context, token *oauth2.Token, r *http.Request) ([]entities.PlaylistInterface, error)
-	ReorderPlaylist(playlistId, criteria, userId string) error
-	DeletePlaylist(playlistId string) error
-	GetPlaylistVideos(playlistId string) ([]entities.VideoInterface, error)
-	GetVideoDetails(videoId string) (entities.VideoInterface, error)
-	CreateNewPlaylist(playlist entities.PlaylistInterface) (string, error)
+	GetAllPlaylists(ctx context.Context, userId string, refresh bool) ([]entities.PlaylistInterface, error)
+	ReorderPlaylist(ctx context.Context, playlistId, criteria, userId string, options entities.PlaylistOptions) error
+	DeletePlaylist(ctx context.Context, playlistId, userId string) error
+	GetPlaylistVideos(ctx context.Context, playlistId, userId string, refresh bool) ([]entities.VideoInterface, error)
+	GetPlaylistsWithVideos(ctx context.Context, userId string, playlists []entities.PlaylistInterface, refresh bool) ([]entities.PlaylistInterface, error)
+	GetVideoDetails(ctx context.Context, videoId, userId string) (entities.VideoInterface, error)
+	GetVideosDetails(ctx context.Context, videoIds []string, userId string) ([]entities.VideoInterface, []string, error)
+	CreateNewPlaylist(ctx context.Context, playlist entities.PlaylistInterface, settings entities.PlaylistSettings, userId string) (string, error)
+	UpdatePlaylist(ctx context.Context, playlistId string, settings entities.PlaylistSettings, userId string) error
+	AddVideoToPlaylist(ctx context.Context, playlistId, videoId, userId string) error
 }
 
 type youtubePlaylistService struct {
 	repo         repository.PlaylistRepositoryRedisInterface
-	Youtube      *youtube.Service
+	videos       repository.VideoRepositoryRedisInterface
+	clients      auth.YoutubeClientProviderInterface
 	errorHandler coreErrors.YouTubeErrorHandler
-	session      sessions.SessionManager
+	quota        quota.LedgerInterface
+	retry        retry.RetrierInterface
+	breakers     circuitbreaker.RegistryInterface
+	// progress é opcional; sem ele o andamento dos jobs não é registrado.
+	progress ports.ProgressReporterInterface
+	// workers limita as buscas paralelas de itens e detalhes de vídeos.
+	workers int
+	// freshFor é a idade máxima do cache antes de uma revalidação em segundo plano.
+	freshFor time.Duration
+	// refreshing guarda as chaves com revalidação em andamento, para não repetir a mesma busca.
+	refreshing sync.Map
 }
 
-func NewYoutubePlaylistService(repo repository.PlaylistRepositoryRedisInterface, eh coreErrors.YouTubeErrorHandler, session sessions.SessionManager) YoutubePlaylistService {
+// Famílias de endpoints da API; cada uma tem o próprio circuit breaker.
+const (
+	familyPlaylists     = "playlists"
+	familyPlaylistItems = "playlistItems"
+	familyVideos        = "videos"
+)
+
+var operationFamilies = map[string]string{
+	"get_all_playlists":      familyPlaylists,
+	"get_playlist":           familyPlaylists,
+	"create_playlist":        familyPlaylists,
+	"update_playlist":        familyPlaylists,
+	"delete_playlist":        familyPlaylists,
+	"get_playlist_video_ids": familyPlaylistItems,
+	"add_video_to_playlist":  familyPlaylistItems,
+	"get_video_details":      familyVideos,
+}
+
+func NewYoutubePlaylistService(repo repository.PlaylistRepositoryRedisInterface, videos repository.VideoRepositoryRedisInterface, clients auth.YoutubeClientProviderInterface, eh coreErrors.YouTubeErrorHandler, ledger quota.LedgerInterface, retrier retry.RetrierInterface, breakers circuitbreaker.RegistryInterface, progress ports.ProgressReporterInterface, workers int, freshFor time.Duration) YoutubePlaylistService {
+	if workers <= 0 {
+		workers = workerpool.DefaultConcurrency
+	}
+	if freshFor <= 0 {
+		freshFor = DefaultFreshFor
+	}
 	return &youtubePlaylistService{
 		repo:         repo,
+		videos:       videos,
+		clients:      clients,
 		errorHandler: eh,
-		session:      session,
+		quota:        ledger,
+		retry:        retrier,
+		breakers:     breakers,
+		progress:     progress,
+		workers:      workers,
+		freshFor:     freshFor,
 	}
 }
 
-func (s *youtubePlaylistService) getYoutubeService(ctx context.Context, token *oauth2.Token) (*youtube.Service, error) {
-	if s.Youtube == nil {
-		service, err := youtube.NewService(ctx, option.WithTokenSource(oauth2.StaticTokenSource(token)))
-		if err != nil {
-			return nil, err
+// call executa uma chamada ao YouTube com a política de retentativa da operação.
+// Cada tentativa passa pelo breaker da família; com o breaker aberto a chamada falha
+// na hora com circuitbreaker.ErrOpen, que não é repetido.
+func (s *youtubePlaylistService) call(ctx context.Context, operation string, fn func() error) error {
+	return s.retry.Do(ctx, operation, func() error {
+		if s.breakers == nil {
+			return fn()
 		}
-		s.Youtube = service
+		return s.breakers.Execute(operationFamilies[operation], fn)
+	})
+}
+
+// allow confere se todas as famílias usadas por uma operação aceitam chamadas agora.
+func (s *youtubePlaylistService) allow(families ...string) error {
+	if s.breakers == nil {
+		return nil
+	}
+	for _, family := range families {
+		if err := s.breakers.Allow(family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBudget confere, antes de iniciar uma operação, se ainda há cota para as unidades previstas.
+// Sem cota, job é adiado pelo tratador de erros até o orçamento ser zerado.
+func (s *youtubePlaylistService) checkBudget(ctx context.Context, job coreErrors.Job, units int64) error {
+	if err := s.withinBudget(ctx, job.UserId, units); err != nil {
+		return s.errorHandler.HandleYouTubeError(ctx, err, job)
 	}
-	return s.Youtube, nil
+	return nil
 }
 
-func (s *youtubePlaylistService) GetAllPlaylists(ctx context.Context, token *oauth2.Token, r *http.Request) ([]entities.PlaylistInterface, error) {
-	// Obter serviço do YouTube
-	ytService, err := s.getYoutubeService(ctx, token)
+func (s *youtubePlaylistService) withinBudget(ctx context.Context, userId string, units int64) error {
+	if s.quota == nil {
+		return nil
+	}
+	if err := s.quota.CheckBudget(ctx, userId, units); err != nil {
+		logging.Info("Operação bloqueada pelo orçamento de cota", zap.String("user_id", userId), zap.Int64("units", units), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s *youtubePlaylistService) getYoutubeService(ctx context.Context, userId string) (*youtube.Service, error) {
+	service, err := s.clients.ClientFor(ctx, userId)
 	if err != nil {
-		logging.Error("Erro ao obter serviço do YouTube", zap.Error(err))
+		logging.Error("Erro ao obter serviço do YouTube", zap.String("user_id", userId), zap.Error(err))
 		return nil, err
 	}
+	return service, nil
+}
+
+// GetAllPlaylists serve a listagem do cache enquanto ela existir. Uma listagem mais velha
+// que freshFor ainda é devolvida, mas dispara uma revalidação em segundo plano.
+func (s *youtubePlaylistService) GetAllPlaylists(ctx context.Context, userId string, refresh bool) ([]entities.PlaylistInterface, error) {
+	if !refresh {
+		cached, fetchedAt, err := s.cachedPlaylists(ctx, userId)
+		if err == nil && (len(cached) > 0 || !fetchedAt.IsZero()) {
+			logging.Info("Playlists recuperadas do cache", zap.Int("count", len(cached)))
+			if s.isStale(fetchedAt) {
+				s.refreshInBackground(ctx, playlistsRefreshKey(userId), func(ctx context.Context) error {
+					if err := s.allow(familyPlaylists); err != nil {
+						return err
+					}
+					_, err := s.fetchAllPlaylists(ctx, userId)
+					return err
+				})
+			}
+			return cached, nil
+		}
+		if err != nil {
+			logging.Error("Erro ao ler playlists do cache", zap.String("user_id", userId), zap.Error(err))
+		}
+	}
 
-	// Extrair userId da sessão
-	userId := s.session.GetUserId(r)
-	logging.Info("UserID extraído da sessão", zap.String("userID", userId))
+	// Sem cache e com o breaker aberto não há como responder; não vale enfileirar uma leitura.
+	if err := s.allow(familyPlaylists); err != nil {
+		return nil, err
+	}
 
-	// Tentar recuperar playlists do cache (Redis)
-	playlistsByUserID, err := s.repo.GetAllPlaylistsByUserID(userId)
-	if err == nil && len(playlistsByUserID) > 0 {
-		logging.Info("Playlists recuperadas do cache", zap.Int("count", len(playlistsByUserID)))
+	return s.fetchAllPlaylists(ctx, userId)
+}
 
-		return playlistsByUserID, nil
+func (s *youtubePlaylistService) cachedPlaylists(ctx context.Context, userId string) ([]entities.PlaylistInterface, time.Time, error) {
+	fetchedAt, err := s.repo.PlaylistsFetchedAt(ctx, userId)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	playlists, err := s.repo.GetAllPlaylistsByUserID(ctx, userId)
+	if err != nil {
+		return nil, time.Time{}, err
 	}
+	return playlists, fetchedAt, nil
+}
 
-	for i, playlist := range playlistsByUserID {
-		log.Printf("Playlist %d: %+v\n", i, playlist)
+// fetchAllPlaylists busca a listagem no YouTube e substitui a do cache, mesmo quando vazia,
+// para que a ausência de playlists também fique registrada.
+func (s *youtubePlaylistService) fetchAllPlaylists(ctx context.Context, userId string) ([]entities.PlaylistInterface, error) {
+	if err := s.checkBudget(ctx, coreErrors.Job{Action: "get_all_playlists", UserId: userId}, quota.ReadCost); err != nil {
+		return nil, err
 	}
 
-	// Chamar API do YouTube para obter playlists
-	call := ytService.Playlists.List([]string{"id", "snippet", "contentDetails"}).Mine(true).MaxResults(50)
-	response, err := call.Do()
+	ytService, err := s.getYoutubeService(ctx, userId)
 	if err != nil {
-		logging.Error("Erro ao chamar API do YouTube", zap.Error(err))
-		return nil, s.errorHandler.HandleYouTubeError(err, "", "get_all_playlists")
+		return nil, err
 	}
 
-	logging.Info("Número de playlists retornadas pela API", zap.Int("count", len(response.Items)))
-	if len(response.Items) == 0 {
-		logging.Info("Nenhuma playlist encontrada na API")
-		return []entities.PlaylistInterface{}, nil
+	// Chamar API do YouTube para obter playlists, seguindo todas as páginas
+	items, err := s.listMyPlaylists(ctx, ytService)
+	if err != nil {
+		logging.Error("Erro ao chamar API do YouTube", zap.Error(err))
+		return nil, s.errorHandler.HandleYouTubeError(ctx, err, coreErrors.Job{Action: "get_all_playlists", UserId: userId})
 	}
 
-	// Converter a resposta da API em entidades do domínio
-	playlistsEntity := make([]entities.PlaylistInterface, len(response.Items))
-	for i, item := range response.Items {
-		logging.Info("Processando playlist", zap.String("playlistID", item.Id), zap.String("title", item.Snippet.Title))
-		publishTime, err := time.Parse(time.RFC3339, item.Snippet.PublishedAt)
-		if err != nil {
-			logging.Error("Erro ao converter data de publicação", zap.Error(err), zap.String("publishedAt", item.Snippet.PublishedAt))
-			return nil, err
-		}
+	logging.Info("Número de playlists retornadas pela API", zap.Int("count", len(items)))
 
-		videos, err := s.GetPlaylistVideos(item.Id)
+	// Converter a resposta da API em resumos; os vídeos são carregados sob demanda em GetPlaylistVideos
+	playlistsEntity := make([]entities.PlaylistInterface, len(items))
+	for i, item := range items {
+		playlistsEntity[i], err = playlistFromItem(item, nil)
 		if err != nil {
-			logging.Info("error getting videos")
 			return nil, err
 		}
-
-		playlistsEntity[i] = entities.NewPlaylist(
-			item.Id,
-			item.Snippet.ChannelId,
-			item.Snippet.Title,
-			item.Snippet.Description,
-			publishTime,
-			videos,
-		)
 	}
 
 	logging.Info("Salvando playlists no cache", zap.String("userID", userId), zap.Int("count", len(playlistsEntity)))
-	err = s.repo.SaveAllPlaylists(userId, playlistsEntity)
+	err = s.repo.SaveAllPlaylists(ctx, userId, playlistsEntity)
 	if err != nil {
 		logging.Error("Erro ao salvar playlists no cache", zap.Error(err))
-		return nil, s.errorHandler.HandleYouTubeError(err, "", "save_all_playlists")
+		return nil, s.errorHandler.HandleYouTubeError(ctx, err, coreErrors.Job{Action: "save_all_playlists", UserId: userId})
 	}
 	logging.Info("Playlists salvas no cache com sucesso", zap.Int("count", len(playlistsEntity)))
 
 	return playlistsEntity, nil
 }
 
-func (s *youtubePlaylistService) ReorderPlaylist(playlistId, criteria, userId string) error {
-	ytService := s.Youtube
+// isStale indica se um conteúdo buscado em fetchedAt já passou de freshFor. Entradas sem
+// marcação, gravadas antes dela existir, contam como velhas.
+func (s *youtubePlaylistService) isStale(fetchedAt time.Time) bool {
+	return fetchedAt.IsZero() || time.Since(fetchedAt) > s.freshFor
+}
+
+// refreshInBackground roda fn em uma goroutine, no máximo uma por chave. A revalidação
+// continua depois que a requisição termina, limitada por refreshTimeout.
+func (s *youtubePlaylistService) refreshInBackground(ctx context.Context, key string, fn func(ctx context.Context) error) {
+	if _, running := s.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+	go func() {
+		defer cancel()
+		defer s.refreshing.Delete(key)
+
+		if err := fn(ctx); err != nil {
+			logging.Info("Falha ao revalidar o cache em segundo plano", zap.String("key", key), zap.Error(err))
+			return
+		}
+		logging.Info("Cache revalidado em segundo plano", zap.String("key", key))
+	}()
+}
+
+func playlistsRefreshKey(userId string) string {
+	return "playlists:" + userId
+}
+
+func videosRefreshKey(userId, playlistId string) string {
+	return "playlist_videos:" + userId + ":" + playlistId
+}
+
+// listMyPlaylists percorre todas as páginas de Playlists.List do usuário autenticado.
+func (s *youtubePlaylistService) listMyPlaylists(ctx context.Context, service *youtube.Service) ([]*youtube.Playlist, error) {
+	var items []*youtube.Playlist
+	pageToken := ""
+
+	for {
+		call := service.Playlists.List([]string{"id", "snippet", "status", "contentDetails"}).Mine(true).MaxResults(maxVideosPerRequest)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		var response *youtube.PlaylistListResponse
+		err := s.call(ctx, "get_all_playlists", func() (err error) {
+			response, err = call.Context(ctx).Do()
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, response.Items...)
+		if response.NextPageToken == "" {
+			return items, nil
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+func (s *youtubePlaylistService) ReorderPlaylist(ctx context.Context, playlistId, criteria, userId string, options entities.PlaylistOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	// O critério é conferido antes de qualquer chamada ao YouTube ou atualização do job.
+	params := DTOs.ReorderParamsDTO{Criteria: criteria, Options: DTOs.PlaylistOptionsFromEntity(options)}
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	// Adiada, a reordenação é refeita do início com os mesmos critério e opções.
+	job := coreErrors.Job{
+		Action:     "reorder_playlist",
+		PlaylistId: playlistId,
+		UserId:     userId,
+		Params:     params,
+	}
+
+	// Com a API instável a reordenação nem começa: vai para a fila e é refeita quando o breaker fechar.
+	if err := s.allow(familyPlaylists, familyPlaylistItems, familyVideos); err != nil {
+		return s.errorHandler.HandleYouTubeError(ctx, err, job)
+	}
+
+	if err := s.checkBudget(ctx, job, quota.ReadCost); err != nil {
+		return err
+	}
 
-	playlist, err := s.GetPlaylistByID(ytService, playlistId)
+	ytService, err := s.getYoutubeService(ctx, userId)
 	if err != nil {
-		logging.Info("Error getting playlist")
-		return s.errorHandler.HandleYouTubeError(err, playlistId, "reorder_playlist")
+		return err
 	}
 
+	playlist, err := s.GetPlaylistByID(ctx, ytService, playlistId)
+	if err != nil {
+		logging.Error("Erro ao buscar a playlist a reordenar", zap.String("playlist_id", playlistId), zap.Error(err))
+		return s.errorHandler.HandleYouTubeError(ctx, err, job)
+	}
+
+	// Antes de começar as escritas confere o custo completo da operação, agora que o tamanho é conhecido.
+	estimate, err := quota.EstimatePlan(reorderPlan(len(playlist.Videos()), options))
+	if err != nil {
+		return err
+	}
+	if err := s.checkBudget(ctx, job, estimate.Units); err != nil {
+		return err
+	}
 	switch criteria {
 	case "byTitle":
 		playlist.SortByTitle()
@@ -154,64 +368,367 @@ func (s *youtubePlaylistService) ReorderPlaylist(playlistId, criteria, userId st
 	default:
 		return errors.New("invalid criteria")
 	}
+	// O progresso do job conta os vídeos copiados; uma execução adiada recomeça do zero.
+	if s.progress != nil {
+		s.progress.Start(ctx, len(playlist.Videos()))
+	}
+
+	settings := options.Resolve(playlist, criteria, time.Now())
 
-	_, err = s.CreateNewPlaylist(playlist)
+	newPlaylistId, inserted, err := s.createNewPlaylist(ctx, ytService, playlist, settings)
 	if err != nil {
-		logging.Info("Erro creating a new playlist - youtube_service - ln 153", zap.Error(err))
-		return s.errorHandler.HandleYouTubeError(err, playlistId, "reorder_playlist")
+		logging.Error("Erro ao criar a cópia ordenada da playlist", zap.String("playlist_id", playlistId), zap.Error(err))
+		return s.errorHandler.HandleYouTubeError(ctx, err, job)
 	}
 
-	return s.repo.SavePlaylist(userId, playlist)
+	if options.ReplaceOriginal {
+		// Até a original ser removida, uma falha descarta a cópia e a reordenação pode ser refeita inteira.
+		if err := s.replaceOriginal(ctx, ytService, playlist, newPlaylistId, inserted, settings, options, userId); err != nil {
+			return s.errorHandler.HandleYouTubeError(ctx, err, job)
+		}
+		if options.RenameToOriginal {
+			settings.Title = playlist.Title()
+		}
+	}
+
+	// A playlist nova já existe no YouTube; o cache é atualizado mesmo que o cliente tenha desistido.
+	// Uma falha no cache não pode virar erro: a retentativa criaria outra cópia.
+	if err := s.repo.SavePlaylist(context.WithoutCancel(ctx), userId, playlistFromSettings(newPlaylistId, playlist, settings)); err != nil {
+		logging.Error("Erro ao salvar a playlist reordenada no cache", zap.String("playlist_id", newPlaylistId), zap.Error(err))
+	}
+	return nil
+}
+
+// playlistFromSettings monta a entidade de uma playlist recém-criada a partir da origem e das configurações usadas.
+func playlistFromSettings(id string, source entities.PlaylistInterface, settings entities.PlaylistSettings) entities.PlaylistInterface {
+	playlist := entities.NewPlaylist(id, source.ChannelId(), settings.Title, settings.Description, time.Now(), source.Videos())
+	playlist.SetPrivacyStatus(settings.PrivacyStatus)
+	playlist.SetDefaultLanguage(settings.DefaultLanguage)
+	playlist.SetTags(settings.Tags)
+	return playlist
 }
 
-func (s *youtubePlaylistService) DeletePlaylist(playlistId string) error {
-	err := s.Youtube.Playlists.Delete(playlistId).Do()
+func reorderPlan(items int, options entities.PlaylistOptions) quota.Plan {
+	plan := quota.Plan{Operation: quota.PlanReorder, Items: items}
+	if options.ReplaceOriginal {
+		plan.Operation = quota.PlanReorderReplace
+		plan.Rename = options.RenameToOriginal
+	}
+	return plan
+}
+
+func (s *youtubePlaylistService) DeletePlaylist(ctx context.Context, playlistId, userId string) error {
+	job := coreErrors.Job{Action: "delete_playlist", PlaylistId: playlistId, UserId: userId}
+	if err := s.checkBudget(ctx, job, quota.WriteCost); err != nil {
+		return err
+	}
+
+	ytService, err := s.getYoutubeService(ctx, userId)
 	if err != nil {
-		return s.errorHandler.HandleYouTubeError(err, playlistId, "delete_playlist")
+		return err
+	}
+
+	if err := s.deletePlaylist(ctx, ytService, userId, playlistId); err != nil {
+		return s.errorHandler.HandleYouTubeError(ctx, err, job)
 	}
-	return s.repo.DeletePlaylist(playlistId)
+	return nil
 }
 
-func (s *youtubePlaylistService) GetPlaylistVideos(playlistId string) ([]entities.VideoInterface, error) {
-	var videos []entities.VideoInterface
-	pageToken := ""
-	for {
-		videoIds, nextPageToken, err := s.getPlaylistVideoIds(playlistId, pageToken)
+func (s *youtubePlaylistService) deletePlaylist(ctx context.Context, service *youtube.Service, userId, playlistId string) error {
+	err := s.call(ctx, "delete_playlist", func() error {
+		return service.Playlists.Delete(playlistId).Context(ctx).Do()
+	})
+	if err != nil {
+		return err
+	}
+
+	// O YouTube é a fonte da verdade: a playlist já foi removida, então uma falha no cache não deve virar erro.
+	if err := s.repo.DeletePlaylist(context.WithoutCancel(ctx), userId, playlistId); err != nil {
+		logging.Info("Playlist removida do YouTube, mas não do cache", zap.String("playlist_id", playlistId), zap.Error(err))
+	}
+	return nil
+}
+
+// GetPlaylistVideos carrega os vídeos de uma playlist, servindo do cache quando possível.
+// Com o cache vazio, busca no YouTube e guarda o resultado separado do resumo da playlist;
+// com o cache velho, devolve o que tem e revalida em segundo plano.
+func (s *youtubePlaylistService) GetPlaylistVideos(ctx context.Context, playlistId, userId string, refresh bool) ([]entities.VideoInterface, error) {
+	if !refresh {
+		cached, err := s.repo.GetPlaylistVideos(ctx, userId, playlistId)
+		if err == nil {
+			logging.Info("Vídeos recuperados do cache", zap.String("playlist_id", playlistId), zap.Int("count", len(cached)))
+			s.revalidateVideos(ctx, playlistId, userId)
+			return cached, nil
+		}
+		if !errors.Is(err, repository.ErrVideosNotCached) {
+			logging.Error("Erro ao ler vídeos do cache", zap.String("playlist_id", playlistId), zap.Error(err))
+		}
+	}
+
+	// Sem cache e com o breaker aberto não há como responder; não vale enfileirar uma leitura.
+	if err := s.allow(familyPlaylistItems, familyVideos); err != nil {
+		return nil, err
+	}
+
+	return s.fetchPlaylistVideos(ctx, playlistId, userId)
+}
+
+func (s *youtubePlaylistService) fetchPlaylistVideos(ctx context.Context, playlistId, userId string) ([]entities.VideoInterface, error) {
+	if err := s.checkBudget(ctx, playlistVideosJob(playlistId, userId), quota.ReadCost); err != nil {
+		return nil, err
+	}
+
+	ytService, err := s.getYoutubeService(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	videos, err := s.getPlaylistVideos(ctx, ytService, playlistId)
+	if err != nil {
+		return nil, s.errorHandler.HandleYouTubeError(ctx, err, playlistVideosJob(playlistId, userId))
+	}
+
+	if err := s.repo.SavePlaylistVideos(ctx, userId, playlistId, videos); err != nil {
+		logging.Error("Erro ao salvar vídeos no cache", zap.String("playlist_id", playlistId), zap.Error(err))
+	}
+	return videos, nil
+}
+
+// revalidateVideos agenda a revalidação dos vídeos em cache quando eles já passaram de freshFor.
+func (s *youtubePlaylistService) revalidateVideos(ctx context.Context, playlistId, userId string) {
+	fetchedAt, err := s.repo.VideosFetchedAt(ctx, userId, playlistId)
+	if err != nil || !s.isStale(fetchedAt) {
+		return
+	}
+	s.refreshInBackground(ctx, videosRefreshKey(userId, playlistId), func(ctx context.Context) error {
+		if err := s.allow(familyPlaylistItems, familyVideos); err != nil {
+			return err
+		}
+		_, err := s.fetchPlaylistVideos(ctx, playlistId, userId)
+		return err
+	})
+}
+
+// GetPlaylistsWithVideos completa os resumos com os vídeos de cada playlist. As que já estão
+// em cache são servidas do Redis (e revalidadas em segundo plano se estiverem velhas); as
+// demais são buscadas em paralelo pelo pool de workers.
+func (s *youtubePlaylistService) GetPlaylistsWithVideos(ctx context.Context, userId string, playlists []entities.PlaylistInterface, refresh bool) ([]entities.PlaylistInterface, error) {
+	var pending []entities.PlaylistInterface
+	for _, playlist := range playlists {
+		if refresh {
+			pending = append(pending, playlist)
+			continue
+		}
+		videos, err := s.repo.GetPlaylistVideos(ctx, userId, playlist.Id())
+		if err != nil {
+			pending = append(pending, playlist)
+			continue
+		}
+		playlist.SetVideos(videos)
+		s.revalidateVideos(ctx, playlist.Id(), userId)
+	}
+	if len(pending) == 0 {
+		return playlists, nil
+	}
+
+	if err := s.allow(familyPlaylistItems, familyVideos); err != nil {
+		return nil, err
+	}
+	// Não há uma ação da fila que refaça a leitura agregada: sem cota, o erro só volta ao cliente.
+	if err := s.withinBudget(ctx, userId, quota.ReadCost*int64(len(pending))); err != nil {
+		return nil, err
+	}
+
+	ytService, err := s.getYoutubeService(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := workerpool.Map(ctx, s.workers, pending, func(ctx context.Context, playlist entities.PlaylistInterface) ([]entities.VideoInterface, error) {
+		videos, err := s.getPlaylistVideos(ctx, ytService, playlist.Id())
 		if err != nil {
-			return nil, s.errorHandler.HandleYouTubeError(err, playlistId, "get_playlist_videos")
+			return nil, s.errorHandler.HandleYouTubeError(ctx, err, playlistVideosJob(playlist.Id(), userId))
 		}
+		return videos, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, playlist := range pending {
+		playlist.SetVideos(contents[i])
+		if err := s.repo.SavePlaylistVideos(ctx, userId, playlist.Id(), contents[i]); err != nil {
+			logging.Error("Erro ao salvar vídeos no cache", zap.String("playlist_id", playlist.Id()), zap.Error(err))
+		}
+	}
+	return playlists, nil
+}
+
+func playlistVideosJob(playlistId, userId string) coreErrors.Job {
+	return coreErrors.Job{Action: "get_playlist_videos", PlaylistId: playlistId, UserId: userId}
+}
 
-		for _, videoId := range videoIds {
-			video, err := s.GetVideoDetails(videoId)
+func (s *youtubePlaylistService) getPlaylistVideos(ctx context.Context, service *youtube.Service, playlistId string) ([]entities.VideoInterface, error) {
+	videoIds, err := s.listPlaylistVideoIds(ctx, service, playlistId)
+	if err != nil {
+		return nil, err
+	}
+
+	found := s.cachedVideos(ctx, uniqueVideoIds(videoIds))
+	videos, missing, err := s.getVideosDetails(ctx, service, videoIds, found)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(missing) > 0 {
+		logging.Info("Vídeos da playlist sem detalhes disponíveis", zap.String("playlist_id", playlistId), zap.Strings("missing", missing))
+	}
+
+	return videos, nil
+}
+
+func (s *youtubePlaylistService) GetVideoDetails(ctx context.Context, videoId, userId string) (entities.VideoInterface, error) {
+	videos, _, err := s.GetVideosDetails(ctx, []string{videoId}, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(videos) == 0 {
+		return nil, errors.New("video not found")
+	}
+
+	return videos[0], nil
+}
+
+// GetVideosDetails busca os detalhes em lotes de até maxVideosPerRequest ids por chamada.
+// Os vídeos encontrados voltam na mesma ordem de videoIds (incluindo repetidos) e os ids
+// sem detalhes (removidos, privados ou com dados inválidos) voltam separados, sem repetição.
+// Quando todos estão no cache compartilhado de vídeos, o YouTube nem é chamado.
+func (s *youtubePlaylistService) GetVideosDetails(ctx context.Context, videoIds []string, userId string) ([]entities.VideoInterface, []string, error) {
+	uniqueIds := uniqueVideoIds(videoIds)
+	found := s.cachedVideos(ctx, uniqueIds)
+	if len(found) == len(uniqueIds) {
+		return orderVideos(videoIds, found), nil, nil
+	}
+
+	job := coreErrors.Job{Action: "get_video_details", UserId: userId, Params: DTOs.VideoDetailsParamsDTO{VideoIds: videoIds}}
+	if err := s.checkBudget(ctx, job, quota.ReadCost); err != nil {
+		return nil, nil, err
+	}
+
+	ytService, err := s.getYoutubeService(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	videos, missing, err := s.getVideosDetails(ctx, ytService, videoIds, found)
+	if err != nil {
+		return nil, nil, s.errorHandler.HandleYouTubeError(ctx, err, job)
+	}
+	return videos, missing, nil
+}
+
+// getVideosDetails só busca no YouTube os vídeos que não estão em found (o que o chamador já
+// leu do cache compartilhado) e grava os buscados no cache. found é completado com eles.
+func (s *youtubePlaylistService) getVideosDetails(ctx context.Context, service *youtube.Service, videoIds []string, found map[string]entities.VideoInterface) ([]entities.VideoInterface, []string, error) {
+	uniqueIds := uniqueVideoIds(videoIds)
+
+	var pending []string
+	for _, id := range uniqueIds {
+		if _, ok := found[id]; !ok {
+			pending = append(pending, id)
+		}
+	}
+
+	var batches [][]string
+	for start := 0; start < len(pending); start += maxVideosPerRequest {
+		batches = append(batches, pending[start:min(start+maxVideosPerRequest, len(pending))])
+	}
+
+	// Os lotes são buscados em paralelo; o erro é tratado uma única vez, depois que o pool para.
+	responses, err := workerpool.Map(ctx, s.workers, batches, func(ctx context.Context, batch []string) (*youtube.VideoListResponse, error) {
+		var response *youtube.VideoListResponse
+		err := s.call(ctx, "get_video_details", func() (err error) {
+			response, err = service.Videos.List([]string{"snippet", "contentDetails"}).Id(batch...).Context(ctx).Do()
+			return err
+		})
+		return response, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fetched []entities.VideoInterface
+	for _, response := range responses {
+		for _, item := range response.Items {
+			video, err := videoFromItem(item)
 			if err != nil {
-				log.Printf("Erro ao buscar detalhes do vídeo %s: %v", videoId, err)
+				logging.Error("Erro ao converter detalhes do vídeo", zap.String("video_id", item.Id), zap.Error(err))
 				continue
 			}
-			videos = append(videos, video)
+			found[item.Id] = video
+			fetched = append(fetched, video)
 		}
+	}
 
-		if nextPageToken == "" {
-			break
+	if len(fetched) > 0 && s.videos != nil {
+		if err := s.videos.SaveVideos(ctx, fetched); err != nil {
+			logging.Error("Erro ao salvar vídeos no cache compartilhado", zap.Int("count", len(fetched)), zap.Error(err))
 		}
-		pageToken = nextPageToken
 	}
 
-	return videos, nil
-}
+	var missing []string
+	for _, id := range uniqueIds {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
 
-func (s *youtubePlaylistService) GetVideoDetails(videoId string) (entities.VideoInterface, error) {
-	call := s.Youtube.Videos.List([]string{"snippet", "contentDetails"}).Id(videoId)
+	return orderVideos(videoIds, found), missing, nil
+}
 
-	response, err := call.Do()
+// cachedVideos devolve os vídeos de videoIds presentes no cache compartilhado. Uma falha
+// na leitura só é registrada: os vídeos são buscados no YouTube como se não estivessem lá.
+func (s *youtubePlaylistService) cachedVideos(ctx context.Context, videoIds []string) map[string]entities.VideoInterface {
+	if s.videos == nil || len(videoIds) == 0 {
+		return map[string]entities.VideoInterface{}
+	}
+	found, err := s.videos.GetVideos(ctx, videoIds)
 	if err != nil {
-		return nil, s.errorHandler.HandleYouTubeError(err, videoId, "get_video_details")
+		logging.Error("Erro ao ler vídeos do cache compartilhado", zap.Error(err))
+		return map[string]entities.VideoInterface{}
 	}
+	return found
+}
 
-	if len(response.Items) == 0 {
-		return nil, errors.New("video not found")
+func uniqueVideoIds(videoIds []string) []string {
+	uniqueIds := make([]string, 0, len(videoIds))
+	seen := make(map[string]bool, len(videoIds))
+	for _, id := range videoIds {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIds = append(uniqueIds, id)
+		}
+	}
+	return uniqueIds
+}
+
+// orderVideos monta a lista na ordem de videoIds, incluindo repetidos, pulando os não encontrados.
+func orderVideos(videoIds []string, found map[string]entities.VideoInterface) []entities.VideoInterface {
+	videos := make([]entities.VideoInterface, 0, len(videoIds))
+	for _, id := range videoIds {
+		if video, ok := found[id]; ok {
+			videos = append(videos, video)
+		}
+	}
+	return videos
+}
+
+func videoFromItem(item *youtube.Video) (entities.VideoInterface, error) {
+	if item.Snippet == nil || item.ContentDetails == nil {
+		return nil, errors.New("video sem snippet ou contentDetails")
 	}
 
-	item := response.Items[0]
 	parsedDuration, err := duration.Parse(item.ContentDetails.Duration)
 	if err != nil {
 		return nil, err
@@ -222,15 +739,18 @@ func (s *youtubePlaylistService) GetVideoDetails(videoId string) (entities.Video
 		return nil, err
 	}
 
-	video := entities.NewVideo(item.Id, item.Snippet.Title, item.Snippet.ChannelId, item.Snippet.DefaultAudioLanguage, publishedAt, parsedDuration.ToTimeDuration())
-	return video, nil
+	return entities.NewVideo(item.Id, item.Snippet.Title, item.Snippet.ChannelId, item.Snippet.DefaultAudioLanguage, publishedAt, parsedDuration.ToTimeDuration()), nil
 }
 
-func (s *youtubePlaylistService) getPlaylistVideoIds(playlistId, pageToken string) ([]string, string, error) {
-	call := s.Youtube.PlaylistItems.List([]string{"contentDetails"}).PlaylistId(playlistId).MaxResults(50).PageToken(pageToken)
-	response, err := call.Do()
+func (s *youtubePlaylistService) getPlaylistVideoIds(ctx context.Context, service *youtube.Service, playlistId, pageToken string) ([]string, string, error) {
+	call := service.PlaylistItems.List([]string{"contentDetails"}).PlaylistId(playlistId).MaxResults(maxVideosPerRequest).PageToken(pageToken)
+	var response *youtube.PlaylistItemListResponse
+	err := s.call(ctx, "get_playlist_video_ids", func() (err error) {
+		response, err = call.Context(ctx).Do()
+		return err
+	})
 	if err != nil {
-		return nil, "", s.errorHandler.HandleYouTubeError(err, playlistId, "get_playlist_video_ids")
+		return nil, "", err
 	}
 
 	var ids []string
@@ -243,37 +763,139 @@ func (s *youtubePlaylistService) getPlaylistVideoIds(playlistId, pageToken strin
 	return ids, response.NextPageToken, nil
 }
 
-func (s *youtubePlaylistService) CreateNewPlaylist(playlist entities.PlaylistInterface) (string, error) {
-	call := s.Youtube.Playlists.Insert([]string{"snippet", "status"}, &youtube.Playlist{
+func (s *youtubePlaylistService) CreateNewPlaylist(ctx context.Context, playlist entities.PlaylistInterface, settings entities.PlaylistSettings, userId string) (string, error) {
+	// Só o caminho de clonagem: Playlists.Insert mais um PlaylistItems.Insert por vídeo.
+	if err := s.checkBudget(ctx, createPlaylistJob(playlist, settings, userId), quota.WriteCost*int64(1+len(playlist.Videos()))); err != nil {
+		return "", err
+	}
+
+	ytService, err := s.getYoutubeService(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+
+	newPlaylistId, _, err := s.createNewPlaylist(ctx, ytService, playlist, settings)
+	if err != nil {
+		return "", s.errorHandler.HandleYouTubeError(ctx, err, createPlaylistJob(playlist, settings, userId))
+	}
+
+	// Sem isso a playlist nova só apareceria na listagem depois da próxima revalidação.
+	if err := s.repo.SavePlaylist(context.WithoutCancel(ctx), userId, playlistFromSettings(newPlaylistId, playlist, settings)); err != nil {
+		logging.Error("Erro ao salvar a playlist criada no cache", zap.String("playlist_id", newPlaylistId), zap.Error(err))
+	}
+	return newPlaylistId, nil
+}
+
+// createPlaylistJob guarda os ids dos vídeos na ordem e as configurações já resolvidas da cópia.
+func createPlaylistJob(playlist entities.PlaylistInterface, settings entities.PlaylistSettings, userId string) coreErrors.Job {
+	videoIds := make([]string, len(playlist.Videos()))
+	for i, video := range playlist.Videos() {
+		videoIds[i] = video.Id()
+	}
+	return coreErrors.Job{
+		Action:     "create_playlist",
+		PlaylistId: playlist.Id(),
+		UserId:     userId,
+		Params: DTOs.CreatePlaylistParamsDTO{
+			ChannelId: playlist.ChannelId(),
+			VideoIds:  videoIds,
+			Settings:  DTOs.PlaylistSettingsFromEntity(settings),
+		},
+	}
+}
+
+// createNewPlaylist cria a playlist e copia os vídeos um a um, devolvendo os ids que foram
+// inseridos. Vídeos indisponíveis são pulados; qualquer outra falha, ou o cancelamento de ctx,
+// remove a playlist parcial e volta ao chamador, que adia a operação inteira pelo tratador de erros.
+func (s *youtubePlaylistService) createNewPlaylist(ctx context.Context, service *youtube.Service, playlist entities.PlaylistInterface, settings entities.PlaylistSettings) (string, []string, error) {
+	call := service.Playlists.Insert([]string{"snippet", "status"}, &youtube.Playlist{
 		Snippet: &youtube.PlaylistSnippet{
-			Title:       playlist.Title() + " reorder_playlist_" + time.Now().Format(time.RFC3339),
-			Description: playlist.Description(),
+			Title:           settings.Title,
+			Description:     settings.Description,
+			DefaultLanguage: settings.DefaultLanguage,
+			Tags:            settings.Tags,
 		},
 		Status: &youtube.PlaylistStatus{
-			PrivacyStatus: "public",
+			PrivacyStatus: settings.PrivacyStatus,
 		},
 	})
-	response, err := call.Do()
+	var response *youtube.Playlist
+	err := s.call(ctx, "create_playlist", func() (err error) {
+		response, err = call.Context(ctx).Do()
+		return err
+	})
 	if err != nil {
-		logging.Error("Error on line 251")
-		return "", s.errorHandler.HandleYouTubeError(err, playlist.Id(), "create_playlist")
+		logging.Error("Erro ao criar playlist no YouTube", zap.String("title", settings.Title), zap.Error(err))
+		return "", nil, err
 	}
 
+	inserted := make([]string, 0, len(playlist.Videos()))
 	for _, video := range playlist.Videos() {
-		fmt.Println("-------------------------------------")
-		fmt.Println(video)
-		fmt.Println("--------------------------")
-		err := s.addVideoToPlaylist(response.Id, video.Id())
+		if err := ctx.Err(); err != nil {
+			s.discardCopy(ctx, service, response.Id)
+			return "", nil, err
+		}
+		err := s.addVideoToPlaylist(ctx, service, response.Id, video.Id())
+		// Só falhas do próprio vídeo são puladas. Cota, breaker aberto ou erros transitórios
+		// fariam as inserções restantes falharem também: a cópia é descartada e refeita depois.
+		if err != nil && !videoUnavailable(err) {
+			logging.Error("Cópia da playlist interrompida", zap.String("playlist_id", response.Id), zap.Int("inserted", len(inserted)), zap.Error(err))
+			s.discardCopy(ctx, service, response.Id)
+			return "", nil, err
+		}
+		if s.progress != nil {
+			s.progress.Step(ctx, video.Id(), err)
+		}
 		if err != nil {
-			logging.Error("Erro ao adicionar video a nova playlist", zap.String("video_id", video.Id()), zap.Error(err))
+			logging.Error("Vídeo indisponível ignorado na cópia", zap.String("video_id", video.Id()), zap.Error(err))
 			continue
 		}
+		inserted = append(inserted, video.Id())
+	}
+	return response.Id, inserted, nil
+}
+
+// videoUnavailable indica que a inserção falhou por causa do vídeo, removido ou inexistente,
+// e falharia de novo em qualquer tentativa.
+func videoUnavailable(err error) bool {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return false
+	}
+	if gErr.Code == http.StatusNotFound {
+		return true
+	}
+	for _, detail := range gErr.Errors {
+		if detail.Reason == "videoNotFound" {
+			return true
+		}
 	}
-	return response.Id, nil
+	return false
 }
 
-func (s *youtubePlaylistService) addVideoToPlaylist(playlistId, videoId string) error {
-	call := s.Youtube.PlaylistItems.Insert([]string{"snippet"}, &youtube.PlaylistItem{
+// AddVideoToPlaylist adiciona o vídeo ao fim da playlist; os vídeos dela em cache deixam de valer.
+func (s *youtubePlaylistService) AddVideoToPlaylist(ctx context.Context, playlistId, videoId, userId string) error {
+	job := coreErrors.Job{Action: "add_video_to_playlist", PlaylistId: playlistId, UserId: userId, Params: DTOs.AddVideoParamsDTO{VideoId: videoId}}
+	if err := s.checkBudget(ctx, job, quota.WriteCost); err != nil {
+		return err
+	}
+
+	ytService, err := s.getYoutubeService(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.addVideoToPlaylist(ctx, ytService, playlistId, videoId); err != nil {
+		return s.errorHandler.HandleYouTubeError(ctx, err, job)
+	}
+	if err := s.repo.InvalidatePlaylistVideos(context.WithoutCancel(ctx), userId, playlistId); err != nil {
+		logging.Info("Vídeo adicionado no YouTube, mas o cache não foi invalidado", zap.String("playlist_id", playlistId), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *youtubePlaylistService) addVideoToPlaylist(ctx context.Context, service *youtube.Service, playlistId, videoId string) error {
+	call := service.PlaylistItems.Insert([]string{"snippet"}, &youtube.PlaylistItem{
 		Snippet: &youtube.PlaylistItemSnippet{
 			PlaylistId: playlistId,
 			ResourceId: &youtube.ResourceId{
@@ -282,16 +904,23 @@ func (s *youtubePlaylistService) addVideoToPlaylist(playlistId, videoId string)
 			},
 		},
 	})
-	_, err := call.Do()
+	err := s.call(ctx, "add_video_to_playlist", func() error {
+		_, err := call.Context(ctx).Do()
+		return err
+	})
 	if err != nil {
-		return s.errorHandler.HandleYouTubeError(err, playlistId, "add_video_to_playlist")
+		return err
 	}
 	return nil
 }
 
-func (s *youtubePlaylistService) GetPlaylistByID(service *youtube.Service, playlistID string) (entities.PlaylistInterface, error) {
+func (s *youtubePlaylistService) GetPlaylistByID(ctx context.Context, service *youtube.Service, playlistID string) (entities.PlaylistInterface, error) {
 	call := service.Playlists.List([]string{"snippet", "status", "contentDetails"}).Id(playlistID)
-	response, err := call.Do()
+	var response *youtube.PlaylistListResponse
+	err := s.call(ctx, "get_playlist", func() (err error) {
+		response, err = call.Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar a playlist: %w", err)
 	}
@@ -302,26 +931,53 @@ func (s *youtubePlaylistService) GetPlaylistByID(service *youtube.Service, playl
 
 	responseItem := response.Items[0]
 
-	videos, err := s.GetPlaylistVideos(playlistID)
+	videos, err := s.getPlaylistVideos(ctx, service, playlistID)
 	if err != nil {
 		logging.Error("Erro ao buscar os videos da playlist - youtube_service - ln 301")
 		return nil, err
 	}
 
-	publishTime, err := time.Parse(time.RFC3339, responseItem.Snippet.PublishedAt)
+	return playlistFromItem(responseItem, videos)
+}
+
+// playlistFromItem converte um item de Playlists.List (partes snippet, status e contentDetails) na entidade.
+func playlistFromItem(item *youtube.Playlist, videos []entities.VideoInterface) (entities.PlaylistInterface, error) {
+	publishTime, err := time.Parse(time.RFC3339, item.Snippet.PublishedAt)
 	if err != nil {
-		logging.Error("Erro ao converter data de publicação", zap.Error(err), zap.String("publishedAt", responseItem.Snippet.PublishedAt))
+		logging.Error("Erro ao converter data de publicação", zap.Error(err), zap.String("publishedAt", item.Snippet.PublishedAt))
 		return nil, err
 	}
 
 	playlist := entities.NewPlaylist(
-		responseItem.Id,
-		responseItem.Snippet.ChannelId,
-		responseItem.Snippet.Title,
-		responseItem.Snippet.Description,
+		item.Id,
+		item.Snippet.ChannelId,
+		item.Snippet.Title,
+		item.Snippet.Description,
 		publishTime,
 		videos,
 	)
+	playlist.SetDefaultLanguage(item.Snippet.DefaultLanguage)
+	playlist.SetTags(item.Snippet.Tags)
+	playlist.SetThumbnailUrl(thumbnailUrl(item.Snippet.Thumbnails))
+	if item.Status != nil {
+		playlist.SetPrivacyStatus(item.Status.PrivacyStatus)
+	}
+	if item.ContentDetails != nil {
+		playlist.SetItemCount(int(item.ContentDetails.ItemCount))
+	}
 
 	return playlist, nil
 }
+
+// thumbnailUrl escolhe a miniatura média, que é a usada na listagem, com fallback para as demais.
+func thumbnailUrl(thumbnails *youtube.ThumbnailDetails) string {
+	if thumbnails == nil {
+		return ""
+	}
+	for _, thumbnail := range []*youtube.Thumbnail{thumbnails.Medium, thumbnails.High, thumbnails.Default, thumbnails.Standard, thumbnails.Maxres} {
+		if thumbnail != nil && thumbnail.Url != "" {
+			return thumbnail.Url
+		}
+	}
+	return ""
+}
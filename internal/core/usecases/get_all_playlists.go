@@ -2,17 +2,33 @@ package usecases
 
 import (
 	"context"
-	"golang.org/x/oauth2"
-	"net/http"
+	"encoding/base64"
+	"errors"
 	"project/internal/core/entities"
 	"project/internal/core/services"
+	"sort"
 )
 
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+var ErrInvalidCursor = errors.New("cursor inválido")
+
+// PlaylistPage é uma página da listagem; NextCursor vazio indica a última página.
+type PlaylistPage struct {
+	Playlists  []entities.PlaylistInterface
+	NextCursor string
+}
+
 type getAllPlaylistUseCase struct {
 	PlaylistService services.YoutubePlaylistService
 }
 type GetAllPlaylistsUseCase interface {
-	Execute(ctx context.Context, token *oauth2.Token, r *http.Request) ([]entities.PlaylistInterface, error)
+	Execute(ctx context.Context, userId string, refresh bool) ([]entities.PlaylistInterface, error)
+	ExecutePage(ctx context.Context, userId, cursor string, limit int, refresh bool) (PlaylistPage, error)
+	WithVideos(ctx context.Context, userId string, playlists []entities.PlaylistInterface, refresh bool) ([]entities.PlaylistInterface, error)
 }
 
 func NewGetAllPlaylistsUseCase(service services.YoutubePlaylistService) GetAllPlaylistsUseCase {
@@ -21,6 +37,64 @@ func NewGetAllPlaylistsUseCase(service services.YoutubePlaylistService) GetAllPl
 	}
 }
 
-func (uc *getAllPlaylistUseCase) Execute(ctx context.Context, token *oauth2.Token, r *http.Request) ([]entities.PlaylistInterface, error) {
-	return uc.PlaylistService.GetAllPlaylists(ctx, token, r)
+// Execute devolve a listagem completa; com refresh ela é buscada de novo no YouTube.
+func (uc *getAllPlaylistUseCase) Execute(ctx context.Context, userId string, refresh bool) ([]entities.PlaylistInterface, error) {
+	return uc.PlaylistService.GetAllPlaylists(ctx, userId, refresh)
+}
+
+// ExecutePage devolve até limit playlists a partir do cursor. As playlists são ordenadas
+// por id e o cursor guarda o último id entregue, então a paginação continua estável
+// mesmo que playlists sejam criadas ou removidas entre uma página e outra.
+func (uc *getAllPlaylistUseCase) ExecutePage(ctx context.Context, userId, cursor string, limit int, refresh bool) (PlaylistPage, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return PlaylistPage{}, err
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	playlists, err := uc.PlaylistService.GetAllPlaylists(ctx, userId, refresh)
+	if err != nil {
+		return PlaylistPage{}, err
+	}
+
+	sorted := make([]entities.PlaylistInterface, len(playlists))
+	copy(sorted, playlists)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Id() < sorted[j].Id()
+	})
+
+	start := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].Id() > after
+	})
+	end := min(start+limit, len(sorted))
+
+	page := PlaylistPage{Playlists: sorted[start:end]}
+	if end < len(sorted) {
+		page.NextCursor = encodeCursor(sorted[end-1].Id())
+	}
+	return page, nil
+}
+
+// WithVideos carrega os vídeos das playlists informadas; usado depois da paginação para
+// buscar apenas o conteúdo da página.
+func (uc *getAllPlaylistUseCase) WithVideos(ctx context.Context, userId string, playlists []entities.PlaylistInterface, refresh bool) ([]entities.PlaylistInterface, error) {
+	return uc.PlaylistService.GetPlaylistsWithVideos(ctx, userId, playlists, refresh)
+}
+
+func encodeCursor(lastId string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastId))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	lastId, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(lastId) == 0 {
+		return "", ErrInvalidCursor
+	}
+	return string(lastId), nil
 }
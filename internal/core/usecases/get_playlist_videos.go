@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+	"project/internal/core/entities"
+	"project/internal/core/services"
+)
+
+type getPlaylistVideosUseCase struct {
+	PlaylistService services.YoutubePlaylistService
+}
+
+type GetPlaylistVideosUseCaseInterface interface {
+	Execute(ctx context.Context, playlistId, userId string, refresh bool) ([]entities.VideoInterface, error)
+}
+
+func NewGetPlaylistVideosUseCase(service services.YoutubePlaylistService) GetPlaylistVideosUseCaseInterface {
+	return &getPlaylistVideosUseCase{
+		PlaylistService: service,
+	}
+}
+
+func (uc *getPlaylistVideosUseCase) Execute(ctx context.Context, playlistId, userId string, refresh bool) ([]entities.VideoInterface, error) {
+	return uc.PlaylistService.GetPlaylistVideos(ctx, playlistId, userId, refresh)
+}
@@ -2,23 +2,49 @@ package usecases
 
 import (
 	"context"
-	"project/internal/core/services"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"project/internal/DTOs"
+	"project/internal/core/entities"
+	coreErrors "project/internal/core/errors"
+	"project/internal/core/ports"
 )
 
+// ErrInvalidReorder marca pedidos de reordenação que nunca poderiam ser executados.
+var ErrInvalidReorder = errors.New("reordenação inválida")
+
 type reorderPlaylistUseCase struct {
-	PlaylistService services.YoutubePlaylistService
+	Jobs ports.JobSchedulerInterface
 }
 
 type ReorderPlaylistUseCaseInterface interface {
-	Execute(playlistId, criteria, userId string, ctx context.Context) error
+	// Execute enfileira a reordenação e retorna o id do job, sem esperar as chamadas ao YouTube.
+	// Um pedido repetido com a mesma chave de idempotência retorna o id do job original.
+	Execute(ctx context.Context, playlistId, criteria, userId string, options entities.PlaylistOptions) (string, error)
 }
 
-func NewReorderPlaylistUseCase(service services.YoutubePlaylistService) ReorderPlaylistUseCaseInterface {
+func NewReorderPlaylistUseCase(jobs ports.JobSchedulerInterface) ReorderPlaylistUseCaseInterface {
 	return &reorderPlaylistUseCase{
-		PlaylistService: service,
+		Jobs: jobs,
 	}
 }
 
-func (uc *reorderPlaylistUseCase) Execute(playlistId, criteria, userId string, ctx context.Context) error {
-	return uc.PlaylistService.ReorderPlaylist(playlistId, criteria, userId, ctx)
+func (uc *reorderPlaylistUseCase) Execute(ctx context.Context, playlistId, criteria, userId string, options entities.PlaylistOptions) (string, error) {
+	if err := options.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidReorder, err)
+	}
+	reorderParams := DTOs.ReorderParamsDTO{Criteria: criteria, Options: DTOs.PlaylistOptionsFromEntity(options)}
+	if err := reorderParams.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidReorder, err)
+	}
+
+	return uc.Jobs.Schedule(ctx, coreErrors.Job{
+		Action:     "reorder_playlist",
+		PlaylistId: playlistId,
+		UserId:     userId,
+		Params:     reorderParams,
+		JobId:      uuid.NewString(),
+	})
 }
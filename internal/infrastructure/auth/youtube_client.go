@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/markbates/goth/providers/google"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+	"google.golang.org/api/youtube/v3"
+	"project/internal/infrastructure/logging"
+	"project/internal/infrastructure/quota"
+	"project/internal/infrastructure/repository"
+)
+
+var ErrUserNotFound = errors.New("usuário não encontrado")
+
+const (
+	// clientIdleTTL é o tempo sem uso depois do qual o cliente de um usuário é descartado.
+	clientIdleTTL = time.Hour
+	// maxClients limita os clientes guardados; passando dele, os usados há mais tempo saem primeiro.
+	maxClients = 1000
+)
+
+// YoutubeClientProviderInterface entrega um cliente do YouTube autenticado com o token do próprio usuário.
+type YoutubeClientProviderInterface interface {
+	ClientFor(ctx context.Context, userId string) (*youtube.Service, error)
+	// Forget descarta o cliente do usuário, ex.: no logout ou quando o token foi revogado.
+	Forget(userId string)
+}
+
+type cachedClient struct {
+	service      *youtube.Service
+	refreshToken string
+	lastUsed     time.Time
+}
+
+type youtubeClientProvider struct {
+	mu      sync.Mutex
+	clients map[string]cachedClient
+	repo    repository.UserRepositoryInterface
+	oauth   *oauth2.Config
+	ledger  quota.LedgerInterface
+}
+
+// NewYoutubeClientProvider cria o provedor de clientes. Com ledger não nulo, toda chamada
+// feita pelos clientes é contabilizada na cota do usuário.
+func NewYoutubeClientProvider(repo repository.UserRepositoryInterface, config OAuthConfig, ledger quota.LedgerInterface) YoutubeClientProviderInterface {
+	endpoint := config.Endpoint
+	if endpoint.TokenURL == "" {
+		endpoint = google.Endpoint
+	}
+
+	return &youtubeClientProvider{
+		clients: make(map[string]cachedClient),
+		repo:    repo,
+		ledger:  ledger,
+		oauth: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Scopes:       config.Scopes,
+			Endpoint:     endpoint,
+		},
+	}
+}
+
+// ClientFor reaproveita o cliente do usuário enquanto o refresh token armazenado não mudar.
+// Um novo login (com novo refresh token) descarta o cliente antigo automaticamente.
+func (p *youtubeClientProvider) ClientFor(ctx context.Context, userId string) (*youtube.Service, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if userId == "" {
+		return nil, ErrUserNotFound
+	}
+
+	user, err := p.repo.GetUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if cached, ok := p.clients[userId]; ok && cached.refreshToken == user.RefreshToken() && now.Sub(cached.lastUsed) < clientIdleTTL {
+		cached.lastUsed = now
+		p.clients[userId] = cached
+		return cached.service, nil
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  user.Token(),
+		RefreshToken: user.RefreshToken(),
+		Expiry:       user.ExpiresAt(),
+		TokenType:    "Bearer",
+	}
+	// Expiry zero significa "nunca expira" para o oauth2; força a renovação na primeira chamada.
+	if token.Expiry.IsZero() && token.RefreshToken != "" {
+		token.Expiry = time.Unix(1, 0)
+	}
+
+	// O cliente fica em cache e vive mais que a requisição atual, por isso não usa o ctx recebido.
+	source := &persistingTokenSource{
+		base:   p.oauth.TokenSource(context.Background(), token),
+		repo:   p.repo,
+		userId: userId,
+		last:   token.AccessToken,
+		// Com a renovação recusada (token revogado ou expirado), o cliente não serve mais.
+		onFailure: func() { p.forget(userId, token.RefreshToken) },
+	}
+
+	httpClient := oauth2.NewClient(context.Background(), source)
+	if p.ledger != nil {
+		httpClient.Transport = quota.NewTransport(httpClient.Transport, p.ledger, userId)
+	}
+
+	service, err := youtube.NewService(context.Background(), option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+
+	p.evict(now)
+	p.clients[userId] = cachedClient{service: service, refreshToken: user.RefreshToken(), lastUsed: now}
+	return service, nil
+}
+
+func (p *youtubeClientProvider) Forget(userId string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, userId)
+}
+
+// forget só descarta o cliente se ele ainda usa refreshToken; um login novo pode já tê-lo trocado.
+func (p *youtubeClientProvider) forget(userId, refreshToken string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cached, ok := p.clients[userId]; ok && cached.refreshToken == refreshToken {
+		delete(p.clients, userId)
+	}
+}
+
+// evict remove os clientes ociosos e, se o mapa continuar cheio, o usado há mais tempo,
+// abrindo espaço para mais um. Deve ser chamado com p.mu travado.
+func (p *youtubeClientProvider) evict(now time.Time) {
+	var oldestId string
+	var oldest time.Time
+	for id, cached := range p.clients {
+		if now.Sub(cached.lastUsed) >= clientIdleTTL {
+			delete(p.clients, id)
+			continue
+		}
+		if oldestId == "" || cached.lastUsed.Before(oldest) {
+			oldestId, oldest = id, cached.lastUsed
+		}
+	}
+	if len(p.clients) >= maxClients {
+		delete(p.clients, oldestId)
+	}
+}
+
+// persistingTokenSource grava no repositório todo token renovado pelo token source base.
+type persistingTokenSource struct {
+	mu        sync.Mutex
+	base      oauth2.TokenSource
+	repo      repository.UserRepositoryInterface
+	userId    string
+	last      string
+	onFailure func()
+}
+
+func (ts *persistingTokenSource) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	token, err := ts.base.Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && ts.onFailure != nil {
+			logging.Info("Renovação do token recusada, cliente do YouTube descartado", zap.String("user_id", ts.userId), zap.Error(err))
+			ts.onFailure()
+		}
+		return nil, err
+	}
+
+	if token.AccessToken == ts.last {
+		return token, nil
+	}
+	ts.last = token.AccessToken
+
+	// Falhar ao persistir não invalida o token recém-obtido; apenas registra o problema.
+	if err := ts.persist(token); err != nil {
+		logging.Error("Erro ao salvar token renovado", zap.String("user_id", ts.userId), zap.Error(err))
+	}
+
+	return token, nil
+}
+
+// persist roda dentro de Token(), que não recebe contexto; o token já foi renovado e precisa ser salvo.
+func (ts *persistingTokenSource) persist(token *oauth2.Token) error {
+	ctx := context.Background()
+	user, err := ts.repo.GetUserByID(ctx, ts.userId)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	user.SetAccessToken(token.AccessToken)
+	user.SetExpiresAt(token.Expiry)
+	if token.RefreshToken != "" {
+		user.SetRefreshToken(token.RefreshToken)
+	}
+
+	return ts.repo.UpdateUser(ctx, user)
+}
@@ -0,0 +1,40 @@
+package cache
+
+import "fmt"
+
+// Backends aceitos em CACHE_BACKEND.
+const (
+	BackendRedis  = "redis"
+	BackendMemory = "memory"
+)
+
+// DefaultRedisAddress é usado quando REDIS_ADDRESS não é configurado.
+const DefaultRedisAddress = "localhost:6379"
+
+// Options escolhe e configura o backend do cache.
+type Options struct {
+	Backend string
+	Redis   RedisOptions
+	// MaxEntries limita o número de chaves do backend em memória.
+	MaxEntries int
+	// KeyPrefix é acrescentado a todas as chaves, separando ambientes que dividem o mesmo Redis.
+	KeyPrefix string
+}
+
+// New cria o backend descrito em options; sem backend informado, usa o Redis.
+func New(options Options) (RedisCacheInterface, error) {
+	var backend RedisCacheInterface
+	switch options.Backend {
+	case "", BackendRedis:
+		client, err := NewRedisCache(options.Redis)
+		if err != nil {
+			return nil, err
+		}
+		backend = client
+	case BackendMemory:
+		backend = NewMemoryCache(options.MaxEntries)
+	default:
+		return nil, fmt.Errorf("backend de cache desconhecido: %q", options.Backend)
+	}
+	return WithPrefix(backend, options.KeyPrefix), nil
+}
@@ -0,0 +1,316 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultMaxEntries é o número de chaves guardadas pelo cache em memória quando nada é configurado.
+const DefaultMaxEntries = 10000
+
+// ErrWrongType reproduz o WRONGTYPE do Redis: operação de string sobre um hash ou o contrário.
+var ErrWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+// memoryCache implementa RedisCacheInterface dentro do processo, para rodar sem Redis em
+// desenvolvimento, demonstrações e testes. Quando passa de maxEntries chaves, descarta as
+// usadas há mais tempo; chaves expiradas somem na próxima vez que são tocadas.
+type memoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     string
+	hash      map[string]string
+	expiresAt time.Time
+}
+
+func NewMemoryCache(maxEntries int) RedisCacheInterface {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &memoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expiresAt time.Time
+	if current := m.lookup(key); current != nil && expiration == redis.KeepTTL {
+		expiresAt = current.expiresAt
+	} else if expiration > 0 {
+		expiresAt = m.now().Add(expiration)
+	}
+
+	entry := m.store(key)
+	entry.value = toString(value)
+	entry.hash = nil
+	entry.expiresAt = expiresAt
+	return nil
+}
+
+func (m *memoryCache) Get(_ context.Context, key string) (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil {
+		return nil, redis.Nil
+	}
+	if entry.hash != nil {
+		return nil, ErrWrongType
+	}
+	return entry.value, nil
+}
+
+// MGet segue o MGET do Redis: chaves ausentes ou que guardam um hash ficam nil.
+func (m *memoryCache) MGet(_ context.Context, keys []string) ([]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		if entry := m.lookup(key); entry != nil && entry.hash == nil {
+			values[i] = entry.value
+		}
+	}
+	return values, nil
+}
+
+func (m *memoryCache) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	for key, value := range values {
+		if err := m.Set(ctx, key, value, expiration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(key)
+	return nil
+}
+
+func (m *memoryCache) HGet(_ context.Context, key string, field string) (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hash, err := m.hash(key, false)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := hash[field]
+	if !ok {
+		return nil, redis.Nil
+	}
+	return value, nil
+}
+
+func (m *memoryCache) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hash, err := m.hash(key, false)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(hash))
+	for field, value := range hash {
+		result[field] = value
+	}
+	return result, nil
+}
+
+func (m *memoryCache) HSet(_ context.Context, key string, field string, value interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hash, err := m.hash(key, true)
+	if err != nil {
+		return err
+	}
+	hash[field] = toString(value)
+	return nil
+}
+
+func (m *memoryCache) HDel(_ context.Context, key string, field string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hash, err := m.hash(key, false)
+	if err != nil {
+		return err
+	}
+	delete(hash, field)
+	// Como no Redis, o hash sem campos deixa de existir.
+	if len(hash) == 0 {
+		m.remove(key)
+	}
+	return nil
+}
+
+func (m *memoryCache) HIncrBy(_ context.Context, key string, field string, incr int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hash, err := m.hash(key, true)
+	if err != nil {
+		return 0, err
+	}
+	current := int64(0)
+	if value, ok := hash[field]; ok {
+		if current, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return 0, errors.New("ERR hash value is not an integer")
+		}
+	}
+	current += incr
+	hash[field] = strconv.FormatInt(current, 10)
+	return current, nil
+}
+
+func (m *memoryCache) Expire(_ context.Context, key string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil {
+		return nil
+	}
+	if expiration <= 0 {
+		m.remove(key)
+		return nil
+	}
+	entry.expiresAt = m.now().Add(expiration)
+	return nil
+}
+
+func (m *memoryCache) HReplace(_ context.Context, key string, fields map[string]string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(key)
+	if len(fields) == 0 {
+		return nil
+	}
+	entry := m.store(key)
+	entry.hash = make(map[string]string, len(fields))
+	for field, value := range fields {
+		entry.hash[field] = value
+	}
+	if expiration > 0 {
+		entry.expiresAt = m.now().Add(expiration)
+	}
+	return nil
+}
+
+// Keys aceita os padrões glob do SCAN que path.Match também entende (*, ? e classes).
+func (m *memoryCache) Keys(_ context.Context, pattern string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var keys []string
+	for key, element := range m.entries {
+		if m.expired(element.Value.(*memoryEntry)) {
+			m.remove(key)
+			continue
+		}
+		matched, err := path.Match(pattern, key)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// lookup devolve a entrada viva de key, marcando-a como usada agora, ou nil.
+func (m *memoryCache) lookup(key string) *memoryEntry {
+	element, ok := m.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if m.expired(entry) {
+		m.remove(key)
+		return nil
+	}
+	m.lru.MoveToFront(element)
+	return entry
+}
+
+// store devolve a entrada de key, criando-a se preciso e descartando a menos usada quando o limite é atingido.
+func (m *memoryCache) store(key string) *memoryEntry {
+	if entry := m.lookup(key); entry != nil {
+		return entry
+	}
+
+	entry := &memoryEntry{key: key}
+	m.entries[key] = m.lru.PushFront(entry)
+	for m.lru.Len() > m.maxEntries {
+		oldest := m.lru.Back()
+		m.remove(oldest.Value.(*memoryEntry).key)
+	}
+	return entry
+}
+
+// hash devolve o hash guardado em key; com create, cria um vazio quando a chave não existe.
+func (m *memoryCache) hash(key string, create bool) (map[string]string, error) {
+	entry := m.lookup(key)
+	if entry == nil {
+		if !create {
+			return map[string]string{}, nil
+		}
+		entry = m.store(key)
+		entry.hash = make(map[string]string)
+	}
+	if entry.hash == nil {
+		return nil, ErrWrongType
+	}
+	return entry.hash, nil
+}
+
+func (m *memoryCache) remove(key string) {
+	if element, ok := m.entries[key]; ok {
+		m.lru.Remove(element)
+		delete(m.entries, key)
+	}
+}
+
+func (m *memoryCache) expired(entry *memoryEntry) bool {
+	return !entry.expiresAt.IsZero() && !m.now().Before(entry.expiresAt)
+}
+
+// toString converte o valor como o go-redis faz ao enviá-lo ao Redis.
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,115 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"project/internal/infrastructure/cache"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache(2)
+
+	_ = c.Set(ctx, "a", "1", 0)
+	_ = c.Set(ctx, "b", "2", 0)
+	// Ler "a" faz de "b" a chave usada há mais tempo.
+	if _, err := c.Get(ctx, "a"); err != nil {
+		t.Fatalf("Erro ao ler a: %v", err)
+	}
+	_ = c.HSet(ctx, "c", "field", "3")
+
+	if _, err := c.Get(ctx, "b"); !errors.Is(err, redis.Nil) {
+		t.Errorf("Esperado b descartado, obtido %v", err)
+	}
+	keys, _ := c.Keys(ctx, "*")
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "c" {
+		t.Errorf("Esperado apenas a e c, obtido %v", keys)
+	}
+}
+
+func TestMemoryCacheExpiresAndKeepsTTL(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache(0)
+
+	_ = c.Set(ctx, "video:1", "v1", 50*time.Millisecond)
+	// Regravar com KeepTTL, como faz o migrate-cache, não pode tornar a chave permanente.
+	_ = c.Set(ctx, "video:1", "v2", redis.KeepTTL)
+	if value, err := c.Get(ctx, "video:1"); err != nil || value != "v2" {
+		t.Fatalf("Esperado v2, obtido %v (%v)", value, err)
+	}
+
+	if _, err := c.HIncrBy(ctx, "quota", "total", 3); err != nil {
+		t.Fatalf("Erro no HIncrBy: %v", err)
+	}
+	_ = c.Expire(ctx, "quota", 50*time.Millisecond)
+
+	time.Sleep(80 * time.Millisecond)
+
+	if _, err := c.Get(ctx, "video:1"); !errors.Is(err, redis.Nil) {
+		t.Errorf("Esperado video:1 expirado, obtido %v", err)
+	}
+	if counters, _ := c.HGetAll(ctx, "quota"); len(counters) != 0 {
+		t.Errorf("Esperado hash expirado, obtido %v", counters)
+	}
+	if _, err := c.Get(ctx, "quota"); !errors.Is(err, redis.Nil) {
+		t.Errorf("Esperado quota expirada, obtido %v", err)
+	}
+}
+
+func TestMemoryCacheRejectsWrongType(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache(0)
+
+	_ = c.HSet(ctx, "playlists:user", "p1", "{}")
+	if _, err := c.Get(ctx, "playlists:user"); !errors.Is(err, cache.ErrWrongType) {
+		t.Errorf("Esperado ErrWrongType ao ler um hash como string, obtido %v", err)
+	}
+}
+
+func TestMemoryCacheHReplaceSwapsTheWholeHash(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache(0)
+
+	_ = c.HSet(ctx, "playlists:user", "old", "1")
+	if err := c.HReplace(ctx, "playlists:user", map[string]string{"a": "1", "b": "2"}, 50*time.Millisecond); err != nil {
+		t.Fatalf("Erro no HReplace: %v", err)
+	}
+	if fields, _ := c.HGetAll(ctx, "playlists:user"); len(fields) != 2 || fields["old"] != "" {
+		t.Errorf("Esperado só os campos novos, obtido %v", fields)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if fields, _ := c.HGetAll(ctx, "playlists:user"); len(fields) != 0 {
+		t.Errorf("Esperado hash expirado, obtido %v", fields)
+	}
+
+	_ = c.HSet(ctx, "playlists:user", "old", "1")
+	_ = c.HReplace(ctx, "playlists:user", nil, time.Minute)
+	if fields, _ := c.HGetAll(ctx, "playlists:user"); len(fields) != 0 {
+		t.Errorf("Sem campos o hash deveria ser removido, obtido %v", fields)
+	}
+}
+
+func TestMemoryCacheMGetAndSetMany(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache(0)
+
+	if err := c.SetMany(ctx, map[string]interface{}{"video:1": "v1", "video:2": "v2"}, time.Hour); err != nil {
+		t.Fatalf("Erro ao gravar em lote: %v", err)
+	}
+	_ = c.HSet(ctx, "playlists:u1", "p1", "x")
+
+	values, err := c.MGet(ctx, []string{"video:2", "video:3", "playlists:u1", "video:1"})
+	if err != nil {
+		t.Fatalf("Erro ao ler em lote: %v", err)
+	}
+	if len(values) != 4 || values[0] != "v2" || values[1] != nil || values[2] != nil || values[3] != "v1" {
+		t.Errorf("Esperado [v2 <nil> <nil> v1], obtido %v", values)
+	}
+}
@@ -0,0 +1,229 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Resultados de uma operação observada. Leituras terminam em hit, miss ou error; escritas em ok ou error.
+const (
+	OutcomeHit   = "hit"
+	OutcomeMiss  = "miss"
+	OutcomeOK    = "ok"
+	OutcomeError = "error"
+)
+
+// OperationStats acumula os resultados e a latência de uma operação desde o início do processo.
+type OperationStats struct {
+	Calls        int64   `json:"calls"`
+	Hits         int64   `json:"hits"`
+	Misses       int64   `json:"misses"`
+	Errors       int64   `json:"errors"`
+	HitRatio     float64 `json:"hit_ratio"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+// MetricsInterface registra operações do cache e dos repositórios sobre ele.
+type MetricsInterface interface {
+	Observe(operation, outcome string, latency time.Duration)
+	// Snapshot devolve uma cópia dos contadores, indexada pelo nome da operação.
+	Snapshot() map[string]OperationStats
+}
+
+type metrics struct {
+	mu         sync.Mutex
+	operations map[string]*OperationStats
+}
+
+func NewMetrics() MetricsInterface {
+	return &metrics{operations: make(map[string]*OperationStats)}
+}
+
+func (m *metrics) Observe(operation, outcome string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.operations[operation]
+	if !ok {
+		stats = &OperationStats{}
+		m.operations[operation] = stats
+	}
+
+	stats.Calls++
+	switch outcome {
+	case OutcomeHit:
+		stats.Hits++
+	case OutcomeMiss:
+		stats.Misses++
+	case OutcomeError:
+		stats.Errors++
+	}
+	stats.totalLatency += latency
+	stats.maxLatency = max(stats.maxLatency, latency)
+}
+
+func (m *metrics) Snapshot() map[string]OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]OperationStats, len(m.operations))
+	for operation, stats := range m.operations {
+		copied := *stats
+		if lookups := copied.Hits + copied.Misses; lookups > 0 {
+			copied.HitRatio = float64(copied.Hits) / float64(lookups)
+		}
+		copied.AvgLatencyMs = milliseconds(copied.totalLatency) / float64(copied.Calls)
+		copied.MaxLatencyMs = milliseconds(copied.maxLatency)
+		snapshot[operation] = copied
+	}
+	return snapshot
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// ReadOutcome classifica o resultado de uma leitura: missErr (ex.: redis.Nil) conta como miss.
+func ReadOutcome(err error, missErr error) string {
+	switch {
+	case err == nil:
+		return OutcomeHit
+	case errors.Is(err, missErr):
+		return OutcomeMiss
+	default:
+		return OutcomeError
+	}
+}
+
+// WriteOutcome classifica o resultado de uma escrita.
+func WriteOutcome(err error) string {
+	if err != nil {
+		return OutcomeError
+	}
+	return OutcomeOK
+}
+
+// instrumentedCache registra cada chamada ao backend em metrics, com o nome "cache.<operação>".
+type instrumentedCache struct {
+	next    RedisCacheInterface
+	metrics MetricsInterface
+}
+
+// WithMetrics devolve next com cada operação registrada em metrics.
+func WithMetrics(next RedisCacheInterface, metrics MetricsInterface) RedisCacheInterface {
+	return &instrumentedCache{next: next, metrics: metrics}
+}
+
+func (c *instrumentedCache) observe(operation string, start time.Time, outcome string) {
+	c.metrics.Observe("cache."+operation, outcome, time.Since(start))
+}
+
+func (c *instrumentedCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	start := time.Now()
+	err := c.next.Set(ctx, key, value, expiration)
+	c.observe("set", start, WriteOutcome(err))
+	return err
+}
+
+func (c *instrumentedCache) Get(ctx context.Context, key string) (interface{}, error) {
+	start := time.Now()
+	value, err := c.next.Get(ctx, key)
+	c.observe("get", start, ReadOutcome(err, redis.Nil))
+	return value, err
+}
+
+// MGet conta como hit só quando todas as chaves foram encontradas.
+func (c *instrumentedCache) MGet(ctx context.Context, keys []string) ([]interface{}, error) {
+	start := time.Now()
+	values, err := c.next.MGet(ctx, keys)
+	outcome := ReadOutcome(err, redis.Nil)
+	if err == nil && slices.Contains(values, nil) {
+		outcome = OutcomeMiss
+	}
+	c.observe("mget", start, outcome)
+	return values, err
+}
+
+func (c *instrumentedCache) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	start := time.Now()
+	err := c.next.SetMany(ctx, values, expiration)
+	c.observe("setmany", start, WriteOutcome(err))
+	return err
+}
+
+func (c *instrumentedCache) Delete(ctx context.Context, key string) error {
+	start := time.Now()
+	err := c.next.Delete(ctx, key)
+	c.observe("delete", start, WriteOutcome(err))
+	return err
+}
+
+func (c *instrumentedCache) HGet(ctx context.Context, key string, field string) (interface{}, error) {
+	start := time.Now()
+	value, err := c.next.HGet(ctx, key, field)
+	c.observe("hget", start, ReadOutcome(err, redis.Nil))
+	return value, err
+}
+
+// HGetAll conta um hash vazio como miss, já que o Redis não diferencia hash vazio de chave ausente.
+func (c *instrumentedCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	start := time.Now()
+	value, err := c.next.HGetAll(ctx, key)
+	outcome := ReadOutcome(err, redis.Nil)
+	if err == nil && len(value) == 0 {
+		outcome = OutcomeMiss
+	}
+	c.observe("hgetall", start, outcome)
+	return value, err
+}
+
+func (c *instrumentedCache) HSet(ctx context.Context, key string, field string, value interface{}) error {
+	start := time.Now()
+	err := c.next.HSet(ctx, key, field, value)
+	c.observe("hset", start, WriteOutcome(err))
+	return err
+}
+
+func (c *instrumentedCache) HDel(ctx context.Context, key string, field string) error {
+	start := time.Now()
+	err := c.next.HDel(ctx, key, field)
+	c.observe("hdel", start, WriteOutcome(err))
+	return err
+}
+
+func (c *instrumentedCache) HIncrBy(ctx context.Context, key string, field string, incr int64) (int64, error) {
+	start := time.Now()
+	value, err := c.next.HIncrBy(ctx, key, field, incr)
+	c.observe("hincrby", start, WriteOutcome(err))
+	return value, err
+}
+
+func (c *instrumentedCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	start := time.Now()
+	err := c.next.Expire(ctx, key, expiration)
+	c.observe("expire", start, WriteOutcome(err))
+	return err
+}
+
+func (c *instrumentedCache) HReplace(ctx context.Context, key string, fields map[string]string, expiration time.Duration) error {
+	start := time.Now()
+	err := c.next.HReplace(ctx, key, fields, expiration)
+	c.observe("hreplace", start, WriteOutcome(err))
+	return err
+}
+
+func (c *instrumentedCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	start := time.Now()
+	keys, err := c.next.Keys(ctx, pattern)
+	c.observe("keys", start, WriteOutcome(err))
+	return keys, err
+}
@@ -0,0 +1,31 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+
+	"project/internal/infrastructure/cache"
+)
+
+func TestMetricsCountHitsMissesAndWrites(t *testing.T) {
+	ctx := context.Background()
+	metrics := cache.NewMetrics()
+	c := cache.WithMetrics(cache.NewMemoryCache(0), metrics)
+
+	_ = c.Set(ctx, "video:1", "v1", 0)
+	_, _ = c.Get(ctx, "video:1")
+	_, _ = c.Get(ctx, "video:1")
+	_, _ = c.Get(ctx, "video:2")
+
+	snapshot := metrics.Snapshot()
+	get := snapshot["cache.get"]
+	if get.Calls != 3 || get.Hits != 2 || get.Misses != 1 || get.Errors != 0 {
+		t.Errorf("Contadores de get inesperados: %+v", get)
+	}
+	if get.HitRatio < 0.66 || get.HitRatio > 0.67 {
+		t.Errorf("Esperado hit ratio de 2/3, obtido %f", get.HitRatio)
+	}
+	if set := snapshot["cache.set"]; set.Calls != 1 || set.Hits != 0 {
+		t.Errorf("Escritas não deveriam contar como hit: %+v", set)
+	}
+}
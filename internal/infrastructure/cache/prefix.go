@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// prefixedCache acrescenta um prefixo a todas as chaves, para que vários ambientes dividam
+// o mesmo Redis sem se enxergar. Quem usa o cache continua trabalhando com as chaves sem prefixo.
+type prefixedCache struct {
+	next   RedisCacheInterface
+	prefix string
+}
+
+// WithPrefix devolve next com as chaves prefixadas por prefix; sem prefixo devolve next.
+func WithPrefix(next RedisCacheInterface, prefix string) RedisCacheInterface {
+	if prefix == "" {
+		return next
+	}
+	return &prefixedCache{next: next, prefix: prefix}
+}
+
+func (p *prefixedCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return p.next.Set(ctx, p.prefix+key, value, expiration)
+}
+
+func (p *prefixedCache) Get(ctx context.Context, key string) (interface{}, error) {
+	return p.next.Get(ctx, p.prefix+key)
+}
+
+func (p *prefixedCache) MGet(ctx context.Context, keys []string) ([]interface{}, error) {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = p.prefix + key
+	}
+	return p.next.MGet(ctx, prefixed)
+}
+
+func (p *prefixedCache) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	prefixed := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		prefixed[p.prefix+key] = value
+	}
+	return p.next.SetMany(ctx, prefixed, expiration)
+}
+
+func (p *prefixedCache) Delete(ctx context.Context, key string) error {
+	return p.next.Delete(ctx, p.prefix+key)
+}
+
+func (p *prefixedCache) HGet(ctx context.Context, key string, field string) (interface{}, error) {
+	return p.next.HGet(ctx, p.prefix+key, field)
+}
+
+func (p *prefixedCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return p.next.HGetAll(ctx, p.prefix+key)
+}
+
+func (p *prefixedCache) HSet(ctx context.Context, key string, field string, value interface{}) error {
+	return p.next.HSet(ctx, p.prefix+key, field, value)
+}
+
+func (p *prefixedCache) HDel(ctx context.Context, key string, field string) error {
+	return p.next.HDel(ctx, p.prefix+key, field)
+}
+
+func (p *prefixedCache) HIncrBy(ctx context.Context, key string, field string, incr int64) (int64, error) {
+	return p.next.HIncrBy(ctx, p.prefix+key, field, incr)
+}
+
+func (p *prefixedCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return p.next.Expire(ctx, p.prefix+key, expiration)
+}
+
+func (p *prefixedCache) HReplace(ctx context.Context, key string, fields map[string]string, expiration time.Duration) error {
+	return p.next.HReplace(ctx, p.prefix+key, fields, expiration)
+}
+
+func (p *prefixedCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	keys, err := p.next.Keys(ctx, p.prefix+pattern)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		keys[i] = strings.TrimPrefix(key, p.prefix)
+	}
+	return keys, nil
+}
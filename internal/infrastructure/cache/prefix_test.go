@@ -0,0 +1,36 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+
+	"project/internal/infrastructure/cache"
+)
+
+func TestPrefixSeparatesEnvironments(t *testing.T) {
+	ctx := context.Background()
+	shared := cache.NewMemoryCache(0)
+	staging := cache.WithPrefix(shared, "staging:")
+	production := cache.WithPrefix(shared, "prod:")
+
+	_ = staging.Set(ctx, "video:1", "staging", 0)
+	_ = production.Set(ctx, "video:1", "prod", 0)
+
+	if value, _ := staging.Get(ctx, "video:1"); value != "staging" {
+		t.Errorf("Esperado o valor de staging, obtido %v", value)
+	}
+	keys, err := production.Keys(ctx, "video:*")
+	if err != nil || len(keys) != 1 || keys[0] != "video:1" {
+		t.Errorf("Esperado só video:1, sem prefixo, obtido %v (%v)", keys, err)
+	}
+	if raw, _ := shared.Keys(ctx, "*"); len(raw) != 2 {
+		t.Errorf("Esperado duas chaves no backend compartilhado, obtido %v", raw)
+	}
+}
+
+func TestRedisOptionsRejectsSentinelWithCluster(t *testing.T) {
+	_, err := cache.NewRedisCache(cache.RedisOptions{SentinelMaster: "mymaster", Cluster: true})
+	if err == nil {
+		t.Error("Sentinel e Cluster juntos deveriam ser rejeitados antes de conectar")
+	}
+}
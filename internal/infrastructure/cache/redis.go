@@ -2,70 +2,239 @@ package cache
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"github.com/redis/go-redis/v9"
-	"log"
+	"strings"
+	"sync"
 	"time"
 )
 
 type redisCache struct {
-	client *redis.Client
-	ctx    context.Context
+	client redis.UniversalClient
 }
 
 type RedisCacheInterface interface {
-	Set(key string, value interface{}, expiration time.Duration) error
-	Get(key string) (interface{}, error)
-	Delete(key string) error
-	HGet(key string, field string) (interface{}, error)
-	HGetAll(key string) (map[string]string, error)
-	HSet(key string, field string, value interface{}) error
-	HDel(key string, field string) error
-}
-
-func NewRedisCache(address string) RedisCacheInterface {
-	client := redis.NewClient(&redis.Options{
-		Addr:     address,
-		Password: "",
-		DB:       0,
-	})
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Get(ctx context.Context, key string) (interface{}, error)
+	// MGet lê várias chaves de uma vez; a posição de uma chave ausente fica nil.
+	MGet(ctx context.Context, keys []string) ([]interface{}, error)
+	// SetMany grava todos os valores com a mesma expiração em uma única ida ao servidor.
+	SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error
+	Delete(ctx context.Context, key string) error
+	HGet(ctx context.Context, key string, field string) (interface{}, error)
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	HSet(ctx context.Context, key string, field string, value interface{}) error
+	HDel(ctx context.Context, key string, field string) error
+	HIncrBy(ctx context.Context, key string, field string, incr int64) (int64, error)
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+	// HReplace troca o hash inteiro de key por fields, com a expiração dada, em uma única
+	// transação: quem lê vê o hash antigo ou o novo, nunca um meio-termo.
+	HReplace(ctx context.Context, key string, fields map[string]string, expiration time.Duration) error
+	// Keys lista as chaves que casam com pattern (sintaxe do SCAN), sem bloquear o Redis como KEYS.
+	Keys(ctx context.Context, pattern string) ([]string, error)
+}
+
+// RedisOptions configura a conexão com o Redis. Com SentinelMaster, Addresses são os
+// sentinels; com Cluster, são os nós iniciais do cluster; nos demais casos, o único servidor.
+type RedisOptions struct {
+	Addresses []string
+	// Username é o usuário ACL; vazio usa o usuário default com Password.
+	Username string
+	Password string
+	DB       int
+
+	TLS                   bool
+	TLSServerName         string
+	TLSInsecureSkipVerify bool
+
+	SentinelMaster   string
+	SentinelUsername string
+	SentinelPassword string
+
+	Cluster bool
+
+	PoolSize     int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// NewRedisCache conecta ao Redis (servidor único, Sentinel ou Cluster) e confere a conexão com um PING.
+func NewRedisCache(options RedisOptions) (RedisCacheInterface, error) {
+	if len(options.Addresses) == 0 {
+		options.Addresses = []string{DefaultRedisAddress}
+	}
+	if options.Cluster && options.SentinelMaster != "" {
+		return nil, errors.New("redis: Sentinel e Cluster não podem ser usados juntos")
+	}
+	if options.Cluster && options.DB != 0 {
+		return nil, errors.New("redis: o Cluster só aceita o DB 0")
+	}
+
+	universal := &redis.UniversalOptions{
+		Addrs:            options.Addresses,
+		Username:         options.Username,
+		Password:         options.Password,
+		DB:               options.DB,
+		MasterName:       options.SentinelMaster,
+		SentinelUsername: options.SentinelUsername,
+		SentinelPassword: options.SentinelPassword,
+		PoolSize:         options.PoolSize,
+		DialTimeout:      options.DialTimeout,
+		ReadTimeout:      options.ReadTimeout,
+		WriteTimeout:     options.WriteTimeout,
+	}
+	if options.TLS {
+		universal.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ServerName:         options.TLSServerName,
+			InsecureSkipVerify: options.TLSInsecureSkipVerify,
+		}
+	}
 
-	ctx := context.Background()
+	// O modo é escolhido explicitamente: o NewUniversalClient trataria qualquer lista de endereços como cluster.
+	var client redis.UniversalClient
+	switch {
+	case options.SentinelMaster != "":
+		client = redis.NewFailoverClient(universal.Failover())
+	case options.Cluster:
+		client = redis.NewClusterClient(universal.Cluster())
+	default:
+		client = redis.NewClient(universal.Simple())
+	}
 
-	_, err := client.Ping(ctx).Result()
-	if err != nil {
-		log.Fatalf("redis ping err: %v", err)
+	if _, err := client.Ping(context.Background()).Result(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("redis ping %s: %w", strings.Join(options.Addresses, ","), err)
 	}
 
 	return &redisCache{
 		client: client,
-		ctx:    ctx,
+	}, nil
+}
+
+func (r *redisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return r.client.Set(ctx, key, value, expiration).Err()
+}
+
+func (r *redisCache) Get(ctx context.Context, key string) (interface{}, error) {
+	return r.client.Get(ctx, key).Result()
+}
+
+// MGet usa um pipeline de GETs em vez do MGET: no Cluster as chaves caem em slots diferentes,
+// e o pipeline é dividido por nó.
+func (r *redisCache) MGet(ctx context.Context, keys []string) ([]interface{}, error) {
+	cmds := make([]*redis.StringCmd, len(keys))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, cmd := range cmds {
+		value, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
 	}
+	return values, nil
+}
+
+func (r *redisCache) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			pipe.Set(ctx, key, value, expiration)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *redisCache) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
+
+func (r *redisCache) HSet(ctx context.Context, key string, field string, value interface{}) error {
+	return r.client.HSet(ctx, key, field, value).Err()
+}
+
+func (r *redisCache) HGet(ctx context.Context, key string, field string) (interface{}, error) {
+	return r.client.HGet(ctx, key, field).Result()
 }
 
-func (r *redisCache) Set(key string, value interface{}, expiration time.Duration) error {
-	return r.client.Set(r.ctx, key, value, expiration).Err()
+func (r *redisCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return r.client.HGetAll(ctx, key).Result()
 }
 
-func (r *redisCache) Get(key string) (interface{}, error) {
-	return r.client.Get(r.ctx, key).Result()
+func (r *redisCache) HDel(ctx context.Context, key string, field string) error {
+	return r.client.HDel(ctx, key, field).Err()
 }
 
-func (r *redisCache) Delete(key string) error {
-	return r.client.Del(r.ctx, key).Err()
+func (r *redisCache) HIncrBy(ctx context.Context, key string, field string, incr int64) (int64, error) {
+	return r.client.HIncrBy(ctx, key, field, incr).Result()
 }
 
-func (r *redisCache) HSet(key string, field string, value interface{}) error {
-	return r.client.HSet(r.ctx, key, field, value).Err()
+func (r *redisCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return r.client.Expire(ctx, key, expiration).Err()
 }
 
-func (r *redisCache) HGet(key string, field string) (interface{}, error) {
-	return r.client.HGet(r.ctx, key, field).Result()
+func (r *redisCache) HReplace(ctx context.Context, key string, fields map[string]string, expiration time.Duration) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(fields) == 0 {
+			return nil
+		}
+		pipe.HSet(ctx, key, fields)
+		if expiration > 0 {
+			pipe.Expire(ctx, key, expiration)
+		}
+		return nil
+	})
+	return err
 }
 
-func (r *redisCache) HGetAll(key string) (map[string]string, error) {
-	return r.client.HGetAll(r.ctx, key).Result()
+// Keys percorre o keyspace com SCAN. No Cluster cada master tem só uma parte das chaves,
+// então todos são percorridos.
+func (r *redisCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	cluster, ok := r.client.(*redis.ClusterClient)
+	if !ok {
+		return scanKeys(ctx, r.client, pattern)
+	}
+
+	var mu sync.Mutex
+	var keys []string
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+		masterKeys, err := scanKeys(ctx, master, pattern)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		keys = append(keys, masterKeys...)
+		mu.Unlock()
+		return nil
+	})
+	return keys, err
 }
 
-func (r *redisCache) HDel(key string, field string) error {
-	return r.client.HDel(r.ctx, key, field).Err()
+func scanKeys(ctx context.Context, client redis.Cmdable, pattern string) ([]string, error) {
+	var keys []string
+	iter := client.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	return keys, iter.Err()
 }
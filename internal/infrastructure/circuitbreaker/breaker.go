@@ -0,0 +1,226 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"project/internal/infrastructure/logging"
+)
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+var ErrOpen = errors.New("circuit breaker aberto")
+
+// OpenError informa qual família de endpoints está bloqueada e quando uma nova tentativa será permitida.
+type OpenError struct {
+	Family  string
+	RetryAt time.Time
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s: %s até %s", ErrOpen, e.Family, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *OpenError) Unwrap() error {
+	return ErrOpen
+}
+
+// Settings controla quando o breaker abre e quanto tempo fica aberto antes de testar a API de novo.
+type Settings struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+}
+
+type Status struct {
+	Family              string    `json:"family"`
+	State               State     `json:"state"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	OpenedAt            time.Time `json:"opened_at,omitempty"`
+	RetryAt             time.Time `json:"retry_at,omitempty"`
+}
+
+type breaker struct {
+	mu       sync.Mutex
+	family   string
+	settings Settings
+	state    State
+	failures int
+	openedAt time.Time
+	// Em half-open só uma chamada de teste passa por vez.
+	probing bool
+}
+
+// allow decide se a chamada pode seguir; em half-open libera apenas a chamada de teste.
+func (b *breaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.state = StateHalfOpen
+		b.probing = false
+	}
+
+	switch b.state {
+	case StateOpen:
+		return &OpenError{Family: b.family, RetryAt: b.openedAt.Add(b.settings.OpenTimeout)}
+	case StateHalfOpen:
+		if b.probing {
+			return &OpenError{Family: b.family, RetryAt: now.Add(b.settings.OpenTimeout)}
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+func (b *breaker) record(failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		if b.state != StateClosed {
+			logging.Info("Circuit breaker fechado", zap.String("family", b.family))
+		}
+		b.state = StateClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.settings.FailureThreshold {
+		if b.state != StateOpen {
+			logging.Error("Circuit breaker aberto", zap.String("family", b.family), zap.Int("consecutive_failures", b.failures), zap.Duration("open_timeout", b.settings.OpenTimeout))
+		}
+		b.state = StateOpen
+		b.openedAt = now
+		b.probing = false
+	}
+}
+
+// release libera a chamada de teste sem alterar o estado.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *breaker) status(now time.Time) Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := Status{Family: b.family, State: b.state, ConsecutiveFailures: b.failures}
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.settings.OpenTimeout {
+		status.State = StateHalfOpen
+	}
+	if b.state != StateClosed {
+		status.OpenedAt = b.openedAt
+		status.RetryAt = b.openedAt.Add(b.settings.OpenTimeout)
+	}
+	return status
+}
+
+// RegistryInterface mantém um breaker por família de endpoints da API (playlists, playlistItems, videos...).
+type RegistryInterface interface {
+	Execute(family string, fn func() error) error
+	Allow(family string) error
+	Statuses() []Status
+}
+
+type registry struct {
+	mu        sync.Mutex
+	settings  Settings
+	breakers  map[string]*breaker
+	isFailure func(error) bool
+	now       func() time.Time
+}
+
+// NewRegistry cria os breakers sob demanda. isFailure decide quais erros contam como falha
+// da API; erros de negócio (404, cota excedida etc.) não devem abrir o circuito.
+func NewRegistry(settings Settings, isFailure func(error) bool) RegistryInterface {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = defaultFailureThreshold
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = defaultOpenTimeout
+	}
+	return &registry{
+		settings:  settings,
+		breakers:  make(map[string]*breaker),
+		isFailure: isFailure,
+		now:       time.Now,
+	}
+}
+
+func (r *registry) get(family string) *breaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.breakers[family]
+	if !ok {
+		b = &breaker{family: family, settings: r.settings, state: StateClosed}
+		r.breakers[family] = b
+	}
+	return b
+}
+
+func (r *registry) Execute(family string, fn func() error) error {
+	b := r.get(family)
+	if err := b.allow(r.now()); err != nil {
+		return err
+	}
+
+	err := fn()
+	// Cancelamento e prazo esgotado do chamador (ou o timeout do cliente HTTP, que também
+	// casa com DeadlineExceeded) não dizem nada sobre a saúde da API.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		b.release()
+		return err
+	}
+	b.record(err != nil && r.isFailure(err), r.now())
+	return err
+}
+
+// Allow informa se a família aceita chamadas agora, sem consumir a chamada de teste do half-open.
+func (r *registry) Allow(family string) error {
+	status := r.get(family).status(r.now())
+	if status.State == StateOpen {
+		return &OpenError{Family: family, RetryAt: status.RetryAt}
+	}
+	return nil
+}
+
+func (r *registry) Statuses() []Status {
+	r.mu.Lock()
+	breakers := make([]*breaker, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		breakers = append(breakers, b)
+	}
+	r.mu.Unlock()
+
+	now := r.now()
+	statuses := make([]Status, len(breakers))
+	for i, b := range breakers {
+		statuses[i] = b.status(now)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Family < statuses[j].Family
+	})
+	return statuses
+}
@@ -0,0 +1,90 @@
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"project/internal/infrastructure/circuitbreaker"
+	"project/internal/infrastructure/retry"
+)
+
+var errUnavailable = errors.New("503")
+
+func alwaysFailure(error) bool { return true }
+
+func TestOpensAfterThresholdAndRejectsCalls(t *testing.T) {
+	r := circuitbreaker.NewRegistry(circuitbreaker.Settings{FailureThreshold: 2, OpenTimeout: time.Minute}, alwaysFailure)
+
+	for i := 0; i < 2; i++ {
+		_ = r.Execute("videos", func() error { return errUnavailable })
+	}
+
+	calls := 0
+	err := r.Execute("videos", func() error {
+		calls++
+		return nil
+	})
+
+	var openErr *circuitbreaker.OpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, circuitbreaker.ErrOpen) {
+		t.Fatalf("Esperado OpenError, obtido %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("Com o breaker aberto a chamada não deveria ser feita, feitas %d", calls)
+	}
+	if openErr.Family != "videos" {
+		t.Errorf("Família esperada videos, obtida %s", openErr.Family)
+	}
+
+	// As outras famílias não são afetadas.
+	if err := r.Execute("playlists", func() error { return nil }); err != nil {
+		t.Errorf("Família playlists deveria continuar fechada, obtido %v", err)
+	}
+}
+
+func TestHalfOpenProbeClosesOnSuccess(t *testing.T) {
+	r := circuitbreaker.NewRegistry(circuitbreaker.Settings{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond}, alwaysFailure)
+
+	_ = r.Execute("playlists", func() error { return errUnavailable })
+	if err := r.Allow("playlists"); !errors.Is(err, circuitbreaker.ErrOpen) {
+		t.Fatalf("Esperado breaker aberto, obtido %v", err)
+	}
+
+	time.Sleep(15 * time.Millisecond)
+
+	if err := r.Execute("playlists", func() error { return nil }); err != nil {
+		t.Fatalf("Chamada de teste deveria passar no half-open, obtido %v", err)
+	}
+
+	statuses := r.Statuses()
+	if len(statuses) != 1 || statuses[0].State != circuitbreaker.StateClosed {
+		t.Errorf("Esperado breaker fechado após a chamada de teste, obtido %+v", statuses)
+	}
+}
+
+func TestBusinessErrorsDoNotOpen(t *testing.T) {
+	r := circuitbreaker.NewRegistry(circuitbreaker.Settings{FailureThreshold: 1}, func(err error) bool { return false })
+
+	_ = r.Execute("playlists", func() error { return errors.New("404") })
+
+	if err := r.Allow("playlists"); err != nil {
+		t.Errorf("Erro de negócio não deveria abrir o breaker, obtido %v", err)
+	}
+}
+
+func TestCallerDeadlinesDoNotOpenTheBreaker(t *testing.T) {
+	r := circuitbreaker.NewRegistry(circuitbreaker.Settings{FailureThreshold: 1, OpenTimeout: time.Minute}, retry.IsTransient)
+
+	deadline := &url.Error{Op: "Get", URL: "https://youtube.googleapis.com", Err: context.DeadlineExceeded}
+	_ = r.Execute("videos", func() error { return deadline })
+
+	if err := r.Allow("videos"); err != nil {
+		t.Fatalf("Um prazo esgotado do cliente não deveria abrir o breaker: %v", err)
+	}
+	if retry.IsTransient(deadline) {
+		t.Error("Prazo esgotado do cliente não deveria ser considerado transitório")
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"project/internal/infrastructure/cache"
+	"strings"
+	"time"
+)
+
+// CacheOptions monta as opções do cache a partir do envConfig carregado.
+func CacheOptions() cache.Options {
+	return cache.Options{
+		Backend:    EnvConfigs.CacheBackend,
+		MaxEntries: EnvConfigs.CacheMaxEntries,
+		KeyPrefix:  EnvConfigs.CacheKeyPrefix,
+		Redis: cache.RedisOptions{
+			Addresses:             splitAddresses(EnvConfigs.RedisAddress),
+			Username:              EnvConfigs.RedisUsername,
+			Password:              EnvConfigs.RedisPassword,
+			DB:                    EnvConfigs.RedisDB,
+			TLS:                   EnvConfigs.RedisTLS,
+			TLSServerName:         EnvConfigs.RedisTLSServerName,
+			TLSInsecureSkipVerify: EnvConfigs.RedisTLSInsecureSkipVerify,
+			SentinelMaster:        EnvConfigs.RedisSentinelMaster,
+			SentinelUsername:      EnvConfigs.RedisSentinelUsername,
+			SentinelPassword:      EnvConfigs.RedisSentinelPassword,
+			Cluster:               EnvConfigs.RedisCluster,
+			PoolSize:              EnvConfigs.RedisPoolSize,
+			DialTimeout:           time.Duration(EnvConfigs.RedisDialTimeoutMs) * time.Millisecond,
+			ReadTimeout:           time.Duration(EnvConfigs.RedisReadTimeoutMs) * time.Millisecond,
+			WriteTimeout:          time.Duration(EnvConfigs.RedisWriteTimeoutMs) * time.Millisecond,
+		},
+	}
+}
+
+func splitAddresses(value string) []string {
+	var addresses []string
+	for _, address := range strings.Split(value, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
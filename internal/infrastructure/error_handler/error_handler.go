@@ -1,55 +1,173 @@
 package error_handler
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"go.uber.org/zap"
 	"google.golang.org/api/googleapi"
 	"project/internal/DTOs"
 	coreErrors "project/internal/core/errors"
+	"project/internal/infrastructure/circuitbreaker"
 	"project/internal/infrastructure/logging"
 	"project/internal/infrastructure/messaging"
+	"project/internal/infrastructure/quota"
+	"project/internal/infrastructure/requestctx"
+	"project/internal/infrastructure/retry"
 )
 
+// transient classifica como transitório qualquer erro que uma operação idempotente repetiria.
+var transient = retry.Policy{Idempotent: true}
+
 // ErrorHandler centraliza o tratamento de errors e integra com o sistema de filas.
 type errorHandler struct {
-	producer messaging.RabbitMQProducerInterface
+	queue messaging.JobQueueInterface
 }
 
-func NewErrorHandler(producer messaging.RabbitMQProducerInterface) coreErrors.YouTubeErrorHandler {
-	return &errorHandler{producer: producer}
+func NewErrorHandler(queue messaging.JobQueueInterface) coreErrors.YouTubeErrorHandler {
+	return &errorHandler{queue: queue}
 }
 
 // HandleQuotaExceededError trata o erro de cota excedida e agenda uma re-tentativa.
-func (eh *errorHandler) HandleQuotaExceededError(playlistId string, err *googleapi.Error, action string) error {
-	logging.Info(fmt.Sprintf("Quota excedida para playlist %s", playlistId))
-	message := DTOs.PlaylistActionDTO{
-		ActionName: action,
-		PlaylistId: playlistId,
-		Err:        err.Error(),
-		RetryAt:    time.Now().Add(24 * time.Hour).Unix(),
+func (eh *errorHandler) HandleQuotaExceededError(ctx context.Context, job coreErrors.Job, err *googleapi.Error) error {
+	logging.Info(fmt.Sprintf("Quota excedida para playlist %s", job.PlaylistId), zap.String("user_id", job.UserId), zap.String("request_id", job.RequestId))
+	if qErr := eh.enqueue(ctx, job, err, time.Now().Add(24*time.Hour)); qErr != nil {
+		return qErr
+	}
+	return fmt.Errorf("%w: quota excedida. Ação agendada para reprocessamento em 24 horas", coreErrors.ErrDeferred)
+}
+
+// HandleBudgetExceededError adia a ação até o orçamento de cota do ledger ser zerado. O erro
+// devolvido ainda é ErrBudgetExceeded, para que a API responda 429.
+func (eh *errorHandler) HandleBudgetExceededError(ctx context.Context, job coreErrors.Job, err error) error {
+	retryAt := quota.ResetAt(time.Now())
+	logging.Info(fmt.Sprintf("Orçamento de cota esgotado, ação %s da playlist %s enfileirada", job.Action, job.PlaylistId), zap.String("user_id", job.UserId), zap.String("request_id", job.RequestId), zap.Time("retry_at", retryAt))
+	if qErr := eh.enqueue(ctx, job, err, retryAt); qErr != nil {
+		return qErr
+	}
+	return fmt.Errorf("%w: %w", coreErrors.ErrDeferred, err)
+}
+
+// HandleCircuitOpenError adia a ação até o breaker da família voltar a aceitar chamadas.
+func (eh *errorHandler) HandleCircuitOpenError(ctx context.Context, job coreErrors.Job, err *circuitbreaker.OpenError) error {
+	logging.Info(fmt.Sprintf("Circuit breaker aberto para %s, ação %s da playlist %s enfileirada", err.Family, job.Action, job.PlaylistId), zap.String("user_id", job.UserId), zap.String("request_id", job.RequestId))
+	if qErr := eh.enqueue(ctx, job, err, err.RetryAt); qErr != nil {
+		return qErr
+	}
+	return fmt.Errorf("%w: %w", coreErrors.ErrDeferred, err)
+}
+
+// HandleYouTubeError centraliza o tratamento de errors da API do YouTube. Os erros que
+// podem ser refeitos mais tarde viram uma mensagem com o job completo.
+func (eh *errorHandler) HandleYouTubeError(ctx context.Context, err error, job coreErrors.Job) error {
+	// A ação já foi enfileirada em uma camada mais interna.
+	if errors.Is(err, coreErrors.ErrDeferred) {
+		return err
+	}
+
+	job = withRequestContext(ctx, job)
+
+	var openErr *circuitbreaker.OpenError
+	if errors.As(err, &openErr) {
+		return eh.HandleCircuitOpenError(ctx, job, openErr)
+	}
+	if errors.Is(err, quota.ErrBudgetExceeded) {
+		return eh.HandleBudgetExceededError(ctx, job, err)
+	}
+
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		if gErr.Code == 403 && isQuotaExceeded(gErr) {
+			return eh.HandleQuotaExceededError(ctx, job, gErr)
+		}
+		// Erros transitórios chegam aqui depois de esgotadas as retentativas.
+		if transient.ShouldRetry(gErr) {
+			return fmt.Errorf("erro transitório do YouTube em %s: %w", job.Action, err)
+		}
+	}
+	return fmt.Errorf("erro inesperado: %w", err)
+}
+
+// DeferJob usa a espera do breaker ou da cota quando err vem deles; os demais erros são
+// refeitos depois da primeira espera do consumidor.
+func (eh *errorHandler) DeferJob(ctx context.Context, err error, job coreErrors.Job) error {
+	if errors.Is(err, coreErrors.ErrDeferred) {
+		return err
+	}
+	err = eh.HandleYouTubeError(ctx, err, job)
+	if errors.Is(err, coreErrors.ErrDeferred) {
+		return err
+	}
+
+	job = withRequestContext(ctx, job)
+	logging.Info(fmt.Sprintf("Ação %s da playlist %s enfileirada após falha", job.Action, job.PlaylistId), zap.String("user_id", job.UserId), zap.String("request_id", job.RequestId), zap.Error(err))
+	if qErr := eh.enqueue(ctx, job, err, time.Now().Add(messaging.DefaultRedeliveryPolicy.BaseDelay)); qErr != nil {
+		return qErr
+	}
+	return fmt.Errorf("%w: %w", coreErrors.ErrDeferred, err)
+}
+
+func (eh *errorHandler) enqueue(ctx context.Context, job coreErrors.Job, err error, retryAt time.Time) error {
+	message, mErr := actionFromJob(job, err, retryAt)
+	if mErr != nil {
+		return fmt.Errorf("erro ao serializar mensagem: %v", mErr)
 	}
 	jsonMessage, jErr := json.Marshal(message)
 	if jErr != nil {
 		return fmt.Errorf("erro ao serializar mensagem: %v", jErr)
 	}
-	if pubErr := eh.producer.Publish(string(jsonMessage)); pubErr != nil {
+	// A publicação não pode ser perdida porque o cliente desistiu da requisição.
+	if pubErr := eh.queue.Publish(context.WithoutCancel(ctx), jsonMessage, retryAt); pubErr != nil {
 		return fmt.Errorf("erro ao publicar mensagem: %v", pubErr)
 	}
-	return fmt.Errorf("quota excedida. Ação agendada para reprocessamento em 24 horas")
+	return nil
 }
 
-// HandleYouTubeError centraliza o tratamento de errors da API do YouTube.
-func (eh *errorHandler) HandleYouTubeError(err error, playlistId, action string) error {
-	var gErr *googleapi.Error
-	if errors.As(err, &gErr) {
-		if gErr.Code == 403 && isQuotaExceeded(gErr) {
-			return eh.HandleQuotaExceededError(playlistId, gErr, action)
+// withRequestContext completa o job com a requisição, a chave de idempotência e o job de ctx.
+func withRequestContext(ctx context.Context, job coreErrors.Job) coreErrors.Job {
+	if job.RequestId == "" {
+		job.RequestId = requestctx.RequestId(ctx)
+	}
+	if job.IdempotencyKey == "" {
+		job.IdempotencyKey = requestctx.IdempotencyKey(ctx)
+	}
+	if job.JobId == "" {
+		job.JobId = requestctx.JobId(ctx)
+	}
+	return job
+}
+
+// actionFromJob monta a mensagem da fila. Sem chave do cliente, a chave de idempotência é
+// derivada da requisição e da ação, para que o mesmo job adiado duas vezes tenha a mesma chave.
+func actionFromJob(job coreErrors.Job, err error, retryAt time.Time) (DTOs.PlaylistActionDTO, error) {
+	message := DTOs.PlaylistActionDTO{
+		ActionName:     job.Action,
+		PlaylistId:     job.PlaylistId,
+		UserId:         job.UserId,
+		Err:            err.Error(),
+		RetryAt:        retryAt.Unix(),
+		RequestId:      job.RequestId,
+		IdempotencyKey: job.IdempotencyKey,
+		JobId:          job.JobId,
+	}
+	if job.Params != nil {
+		params, pErr := json.Marshal(job.Params)
+		if pErr != nil {
+			return message, pErr
 		}
+		message.Params = params
+	}
+	if message.IdempotencyKey == "" {
+		sum := sha256.Sum256([]byte(strings.Join([]string{job.RequestId, job.UserId, job.Action, job.PlaylistId, string(message.Params)}, "\x00")))
+		message.IdempotencyKey = hex.EncodeToString(sum[:])
 	}
-	return fmt.Errorf("erro inesperado: %v", err)
+	return message, nil
 }
 
 func isQuotaExceeded(err *googleapi.Error) bool {
@@ -0,0 +1,106 @@
+package error_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"project/internal/DTOs"
+	coreErrors "project/internal/core/errors"
+	"project/internal/infrastructure/circuitbreaker"
+	"project/internal/infrastructure/error_handler"
+	"project/internal/infrastructure/messaging"
+	"project/internal/infrastructure/quota"
+	"project/internal/infrastructure/requestctx"
+)
+
+// nextAction lê a próxima mensagem publicada na fila.
+func nextAction(t *testing.T, queue messaging.JobQueueInterface) DTOs.PlaylistActionDTO {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var action DTOs.PlaylistActionDTO
+	queue.Consume(ctx, func(_ context.Context, message []byte) error {
+		if err := json.Unmarshal(message, &action); err != nil {
+			t.Fatalf("Mensagem inválida: %v", err)
+		}
+		cancel()
+		return nil
+	})
+	if action.ActionName == "" {
+		t.Fatal("Nenhuma mensagem publicada")
+	}
+	return action
+}
+
+func TestDeferredJobCarriesFullContext(t *testing.T) {
+	queue := messaging.NewMemoryQueue()
+	handler := error_handler.NewErrorHandler(queue)
+
+	ctx := requestctx.WithRequestId(context.Background(), "req-1")
+	job := coreErrors.Job{
+		Action:     "reorder_playlist",
+		PlaylistId: "playlist1",
+		UserId:     "user123",
+		Params: DTOs.ReorderParamsDTO{
+			Criteria: "byTitle",
+			Options:  DTOs.PlaylistOptionsDTO{PrivacyStatus: "unlisted", ReplaceOriginal: true},
+		},
+	}
+	openErr := &circuitbreaker.OpenError{Family: "playlists", RetryAt: time.Now()}
+
+	err := handler.HandleYouTubeError(ctx, openErr, job)
+	if !errors.Is(err, coreErrors.ErrDeferred) {
+		t.Fatalf("Esperado ErrDeferred, obtido %v", err)
+	}
+
+	action := nextAction(t, queue)
+	if action.UserId != "user123" || action.PlaylistId != "playlist1" || action.RequestId != "req-1" || action.IdempotencyKey == "" {
+		t.Fatalf("A mensagem deveria trazer usuário, playlist, requisição e chave: %+v", action)
+	}
+	var params DTOs.ReorderParamsDTO
+	if err := json.Unmarshal(action.Params, &params); err != nil {
+		t.Fatalf("Parâmetros inválidos: %v", err)
+	}
+	if params.Criteria != "byTitle" || params.Options.PrivacyStatus != "unlisted" || !params.Options.ReplaceOriginal {
+		t.Errorf("Critério e opções não correspondem ao esperado: %+v", params)
+	}
+
+	// O mesmo job adiado de novo, a partir da ação reprocessada, mantém a chave.
+	firstKey := action.IdempotencyKey
+	if err := handler.HandleYouTubeError(ctx, openErr, job); !errors.Is(err, coreErrors.ErrDeferred) {
+		t.Fatalf("Esperado ErrDeferred, obtido %v", err)
+	}
+	if again := nextAction(t, queue); again.IdempotencyKey != firstKey {
+		t.Errorf("Esperada a mesma chave de idempotência, obtido %q e %q", firstKey, again.IdempotencyKey)
+	}
+}
+
+func TestDeferJobEnqueuesTransientErrors(t *testing.T) {
+	handler := error_handler.NewErrorHandler(messaging.NewMemoryQueue())
+	job := coreErrors.Job{Action: "update_playlist", PlaylistId: "copy1", UserId: "user123"}
+	transientErr := &googleapi.Error{Code: 503}
+
+	if err := handler.HandleYouTubeError(context.Background(), transientErr, job); errors.Is(err, coreErrors.ErrDeferred) {
+		t.Fatalf("HandleYouTubeError não deveria adiar erros transitórios, obtido %v", err)
+	}
+	if err := handler.DeferJob(context.Background(), transientErr, job); !errors.Is(err, coreErrors.ErrDeferred) {
+		t.Errorf("DeferJob deveria enfileirar mesmo erros transitórios, obtido %v", err)
+	}
+}
+
+func TestBudgetExceededIsDeferredUntilReset(t *testing.T) {
+	handler := error_handler.NewErrorHandler(messaging.NewMemoryQueue())
+	job := coreErrors.Job{Action: "reorder_playlist", PlaylistId: "playlist1", UserId: "user123"}
+	budgetErr := fmt.Errorf("%w: usuário", quota.ErrBudgetExceeded)
+
+	err := handler.HandleYouTubeError(context.Background(), budgetErr, job)
+	if !errors.Is(err, coreErrors.ErrDeferred) || !errors.Is(err, quota.ErrBudgetExceeded) {
+		t.Errorf("Esperado ErrDeferred mantendo ErrBudgetExceeded, obtido %v", err)
+	}
+}
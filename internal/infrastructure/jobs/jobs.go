@@ -0,0 +1,64 @@
+// Package jobs guarda o estado dos jobs assíncronos (hoje, a reordenação de playlists) fora
+// do processo, para que qualquer réplica responda a consulta de status.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type State string
+
+const (
+	StateQueued  State = "queued"
+	StateRunning State = "running"
+	// StateDeferred cobre os jobs que esperam a cota do YouTube, o breaker fechar ou uma nova tentativa.
+	StateDeferred           State = "deferred"
+	StateSucceeded          State = "succeeded"
+	StateFailed             State = "failed"
+	StatePartiallySucceeded State = "partially_succeeded"
+)
+
+// MaxErrors limita os erros guardados por job; uma playlist grande pode falhar em centenas de vídeos.
+const MaxErrors = 100
+
+var ErrJobNotFound = errors.New("job não encontrado")
+
+// ErrDuplicateJob indica que o usuário já tem um job com a mesma chave de idempotência.
+var ErrDuplicateJob = errors.New("job já registrado com esta chave de idempotência")
+
+// Status é o estado de um job. Total, Done e Failed contam os itens processados na execução
+// atual; Errors acumula os erros de todas as execuções. IdempotencyKey é a chave enviada pelo
+// cliente ao criar o job, vazia quando não houve.
+type Status struct {
+	Id             string    `json:"id"`
+	UserId         string    `json:"-"`
+	Action         string    `json:"action"`
+	PlaylistId     string    `json:"playlist_id,omitempty"`
+	IdempotencyKey string    `json:"-"`
+	State          State     `json:"state"`
+	Total          int       `json:"total"`
+	Done           int       `json:"done"`
+	Failed         int       `json:"failed"`
+	Errors         []string  `json:"errors"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type StoreInterface interface {
+	// Create grava o job no estado queued. Com uma IdempotencyKey que o usuário já usou,
+	// retorna ErrDuplicateJob.
+	Create(ctx context.Context, status Status) error
+	Get(ctx context.Context, id string) (Status, error)
+	// FindByIdempotencyKey devolve o job que o usuário criou com key, ou ErrJobNotFound.
+	FindByIdempotencyKey(ctx context.Context, userId, key string) (Status, error)
+	// SetState troca o estado e, se errMsg não for vazio, registra o erro.
+	SetState(ctx context.Context, id string, state State, errMsg string) error
+	// Start zera o progresso para uma nova execução com total itens.
+	Start(ctx context.Context, id string, total int) error
+	// Step conta um item processado; com errMsg, conta como falha e registra o erro.
+	Step(ctx context.Context, id string, errMsg string) error
+	// Finish encerra o job como succeeded, ou partially_succeeded se algum item falhou.
+	Finish(ctx context.Context, id string) error
+}
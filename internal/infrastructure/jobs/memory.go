@@ -0,0 +1,122 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Status
+}
+
+// NewMemoryStore guarda o estado dos jobs no processo; só serve para uma réplica.
+func NewMemoryStore() StoreInterface {
+	return &memoryStore{jobs: make(map[string]*Status)}
+}
+
+func (s *memoryStore) Create(_ context.Context, status Status) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.findByIdempotencyKey(status.UserId, status.IdempotencyKey); ok {
+		return ErrDuplicateJob
+	}
+	now := time.Now()
+	status.State = StateQueued
+	status.Total, status.Done, status.Failed = 0, 0, 0
+	status.Errors = []string{}
+	status.CreatedAt, status.UpdatedAt = now, now
+	s.jobs[status.Id] = &status
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, id string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return Status{}, ErrJobNotFound
+	}
+	return job.snapshot(), nil
+}
+
+func (s *memoryStore) FindByIdempotencyKey(_ context.Context, userId, key string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.findByIdempotencyKey(userId, key)
+	if !ok {
+		return Status{}, ErrJobNotFound
+	}
+	return job.snapshot(), nil
+}
+
+func (s *memoryStore) findByIdempotencyKey(userId, key string) (*Status, bool) {
+	if key == "" {
+		return nil, false
+	}
+	for _, job := range s.jobs {
+		if job.UserId == userId && job.IdempotencyKey == key {
+			return job, true
+		}
+	}
+	return nil, false
+}
+
+func (s *memoryStore) SetState(_ context.Context, id string, state State, errMsg string) error {
+	return s.update(id, func(job *Status) {
+		job.State = state
+		job.addError(errMsg)
+	})
+}
+
+func (s *memoryStore) Start(_ context.Context, id string, total int) error {
+	return s.update(id, func(job *Status) {
+		job.Total, job.Done, job.Failed = total, 0, 0
+	})
+}
+
+func (s *memoryStore) Step(_ context.Context, id string, errMsg string) error {
+	return s.update(id, func(job *Status) {
+		if errMsg == "" {
+			job.Done++
+			return
+		}
+		job.Failed++
+		job.addError(errMsg)
+	})
+}
+
+func (s *memoryStore) Finish(_ context.Context, id string) error {
+	return s.update(id, func(job *Status) {
+		job.State = StateSucceeded
+		if job.Failed > 0 {
+			job.State = StatePartiallySucceeded
+		}
+	})
+}
+
+func (s *memoryStore) update(id string, fn func(job *Status)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+	fn(job)
+	job.UpdatedAt = time.Now()
+	return nil
+}
+
+// snapshot copia o job para quem está fora do lock.
+func (job *Status) snapshot() Status {
+	status := *job
+	status.Errors = append([]string{}, job.Errors...)
+	return status
+}
+
+func (job *Status) addError(errMsg string) {
+	if errMsg != "" && len(job.Errors) < MaxErrors {
+		job.Errors = append(job.Errors, errMsg)
+	}
+}
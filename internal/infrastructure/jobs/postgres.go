@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"project/internal/DTOs"
+)
+
+type postgresStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresStore guarda o estado dos jobs na tabela job_statuses. Contadores e erros são
+// atualizados no próprio UPDATE, sem ler a linha antes.
+func NewPostgresStore(db *gorm.DB) (StoreInterface, error) {
+	if db == nil {
+		return nil, errors.New("o estado dos jobs precisa da conexão com o banco")
+	}
+	if err := db.AutoMigrate(&DTOs.JobStatusDTO{}); err != nil {
+		return nil, fmt.Errorf("falha ao criar a tabela de jobs: %v", err)
+	}
+	return &postgresStore{db: db}, nil
+}
+
+func (s *postgresStore) Create(ctx context.Context, status Status) error {
+	dto := DTOs.JobStatusDTO{
+		ID:             status.Id,
+		UserId:         status.UserId,
+		Action:         status.Action,
+		PlaylistId:     status.PlaylistId,
+		IdempotencyKey: status.IdempotencyKey,
+		State:          string(StateQueued),
+		Errors:         []string{},
+	}
+	err := s.db.WithContext(ctx).Create(&dto).Error
+	if err != nil && status.IdempotencyKey != "" {
+		// A violação do índice único só é confirmada relendo a chave: outra réplica criou o job antes.
+		if _, findErr := s.FindByIdempotencyKey(ctx, status.UserId, status.IdempotencyKey); findErr == nil {
+			return ErrDuplicateJob
+		}
+	}
+	return err
+}
+
+func (s *postgresStore) Get(ctx context.Context, id string) (Status, error) {
+	return s.find(ctx, "id = ?", id)
+}
+
+func (s *postgresStore) FindByIdempotencyKey(ctx context.Context, userId, key string) (Status, error) {
+	if key == "" {
+		return Status{}, ErrJobNotFound
+	}
+	return s.find(ctx, "user_id = ? AND idempotency_key = ?", userId, key)
+}
+
+func (s *postgresStore) find(ctx context.Context, query string, args ...any) (Status, error) {
+	var dto DTOs.JobStatusDTO
+	result := s.db.WithContext(ctx).Where(query, args...).Limit(1).Find(&dto)
+	if result.Error != nil {
+		return Status{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Status{}, ErrJobNotFound
+	}
+	return Status{
+		Id:             dto.ID,
+		UserId:         dto.UserId,
+		Action:         dto.Action,
+		PlaylistId:     dto.PlaylistId,
+		IdempotencyKey: dto.IdempotencyKey,
+		State:          State(dto.State),
+		Total:          dto.Total,
+		Done:           dto.Done,
+		Failed:         dto.Failed,
+		Errors:         dto.Errors,
+		CreatedAt:      dto.CreatedAt,
+		UpdatedAt:      dto.UpdatedAt,
+	}, nil
+}
+
+func (s *postgresStore) SetState(ctx context.Context, id string, state State, errMsg string) error {
+	updates := map[string]any{"state": string(state)}
+	if errMsg != "" {
+		updates["errors"] = appendError(errMsg)
+	}
+	return s.update(ctx, id, updates)
+}
+
+func (s *postgresStore) Start(ctx context.Context, id string, total int) error {
+	return s.update(ctx, id, map[string]any{"total": total, "done": 0, "failed": 0})
+}
+
+func (s *postgresStore) Step(ctx context.Context, id string, errMsg string) error {
+	if errMsg == "" {
+		return s.update(ctx, id, map[string]any{"done": gorm.Expr("done + 1")})
+	}
+	return s.update(ctx, id, map[string]any{
+		"failed": gorm.Expr("failed + 1"),
+		"errors": appendError(errMsg),
+	})
+}
+
+func (s *postgresStore) Finish(ctx context.Context, id string) error {
+	return s.update(ctx, id, map[string]any{
+		"state": gorm.Expr("CASE WHEN failed > 0 THEN ? ELSE ? END", string(StatePartiallySucceeded), string(StateSucceeded)),
+	})
+}
+
+func (s *postgresStore) update(ctx context.Context, id string, updates map[string]any) error {
+	result := s.db.WithContext(ctx).Model(&DTOs.JobStatusDTO{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+// appendError acrescenta o erro ao array JSON enquanto ele tiver menos de MaxErrors itens.
+func appendError(errMsg string) any {
+	return gorm.Expr("CASE WHEN jsonb_array_length(errors) < ? THEN errors || jsonb_build_array(?::text) ELSE errors END", MaxErrors, errMsg)
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"project/internal/core/ports"
+	"project/internal/infrastructure/logging"
+)
+
+type trackerKey struct{}
+
+type tracker struct {
+	store StoreInterface
+	id    string
+}
+
+// WithTracker faz Start e Step em ctx atualizarem o progresso do job id.
+func WithTracker(ctx context.Context, store StoreInterface, id string) context.Context {
+	if store == nil || id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, trackerKey{}, tracker{store: store, id: id})
+}
+
+// Start anuncia quantos itens a execução vai processar. Sem job em ctx não faz nada.
+func Start(ctx context.Context, total int) {
+	t, ok := ctx.Value(trackerKey{}).(tracker)
+	if !ok {
+		return
+	}
+	// O progresso é só informativo: uma falha ao gravá-lo não interrompe o job.
+	if err := t.store.Start(context.WithoutCancel(ctx), t.id, total); err != nil {
+		logging.Error("Erro ao gravar o progresso do job", zap.String("job_id", t.id), zap.Error(err))
+	}
+}
+
+// Step conta o item como processado, ou como falha quando err não é nil.
+func Step(ctx context.Context, item string, err error) {
+	t, ok := ctx.Value(trackerKey{}).(tracker)
+	if !ok {
+		return
+	}
+	errMsg := ""
+	if err != nil {
+		errMsg = fmt.Sprintf("%s: %v", item, err)
+	}
+	if sErr := t.store.Step(context.WithoutCancel(ctx), t.id, errMsg); sErr != nil {
+		logging.Error("Erro ao gravar o progresso do job", zap.String("job_id", t.id), zap.Error(sErr))
+	}
+}
+
+type progressReporter struct{}
+
+// NewProgressReporter expõe Start e Step ao núcleo pela porta de progresso.
+func NewProgressReporter() ports.ProgressReporterInterface {
+	return progressReporter{}
+}
+
+func (progressReporter) Start(ctx context.Context, total int) {
+	Start(ctx, total)
+}
+
+func (progressReporter) Step(ctx context.Context, item string, err error) {
+	Step(ctx, item, err)
+}
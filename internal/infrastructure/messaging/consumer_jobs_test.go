@@ -0,0 +1,95 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"project/internal/core/entities"
+	"project/internal/core/services"
+	"project/internal/core/usecases"
+	"project/internal/infrastructure/jobs"
+	"project/internal/infrastructure/messaging"
+	"project/internal/infrastructure/requestctx"
+)
+
+// partialService copia três vídeos e falha no último.
+type partialService struct {
+	services.YoutubePlaylistService
+}
+
+func (s *partialService) ReorderPlaylist(ctx context.Context, playlistId, criteria, userId string, options entities.PlaylistOptions) error {
+	jobs.Start(ctx, 3)
+	jobs.Step(ctx, "video1", nil)
+	jobs.Step(ctx, "video2", nil)
+	jobs.Step(ctx, "video3", errors.New("vídeo indisponível"))
+	return nil
+}
+
+func TestReorderJobReportsProgress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	queue := messaging.NewMemoryQueue()
+	store := jobs.NewMemoryStore()
+
+	jobId, err := usecases.NewReorderPlaylistUseCase(messaging.NewJobScheduler(queue, store)).Execute(ctx, "playlist1", "byTitle", "user123", entities.PlaylistOptions{})
+	if err != nil {
+		t.Fatalf("Erro ao enfileirar a reordenação: %v", err)
+	}
+	if status, _ := store.Get(ctx, jobId); status.State != jobs.StateQueued || status.UserId != "user123" {
+		t.Fatalf("O job deveria começar na fila e pertencer ao usuário: %+v", status)
+	}
+
+	consumer := messaging.NewConsumer(messaging.NewDispatcher(&partialService{}), queue, 3)
+	consumer.Jobs = store
+	go consumer.Start(ctx)
+
+	var status jobs.Status
+	for status.State != jobs.StatePartiallySucceeded && ctx.Err() == nil {
+		time.Sleep(10 * time.Millisecond)
+		status, _ = store.Get(ctx, jobId)
+	}
+	if status.State != jobs.StatePartiallySucceeded {
+		t.Fatalf("Esperado partially_succeeded, obtido %q", status.State)
+	}
+	if status.Total != 3 || status.Done != 2 || status.Failed != 1 || len(status.Errors) != 1 {
+		t.Errorf("Progresso não corresponde ao esperado: %+v", status)
+	}
+
+	if _, err := usecases.NewReorderPlaylistUseCase(messaging.NewJobScheduler(queue, store)).Execute(ctx, "playlist1", "byColor", "user123", entities.PlaylistOptions{}); !errors.Is(err, usecases.ErrInvalidReorder) {
+		t.Errorf("Esperado ErrInvalidReorder para critério desconhecido, obtido %v", err)
+	}
+}
+
+func TestRepeatedIdempotencyKeyReturnsTheOriginalJob(t *testing.T) {
+	ctx := requestctx.WithIdempotencyKey(context.Background(), "key-1")
+	queue := messaging.NewMemoryQueue()
+	store := jobs.NewMemoryStore()
+	reorder := usecases.NewReorderPlaylistUseCase(messaging.NewJobScheduler(queue, store))
+
+	first, err := reorder.Execute(ctx, "playlist1", "byTitle", "user123", entities.PlaylistOptions{})
+	if err != nil {
+		t.Fatalf("Erro ao enfileirar a reordenação: %v", err)
+	}
+	second, err := reorder.Execute(ctx, "playlist1", "byTitle", "user123", entities.PlaylistOptions{})
+	if err != nil || second != first {
+		t.Errorf("Esperado o job original %s no pedido repetido, obtido %s (%v)", first, second, err)
+	}
+
+	other, err := reorder.Execute(ctx, "playlist1", "byTitle", "user456", entities.PlaylistOptions{})
+	if err != nil || other == first {
+		t.Errorf("A mesma chave de outro usuário deveria criar outro job, obtido %s (%v)", other, err)
+	}
+
+	handled := 0
+	consumeCtx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_ = queue.Consume(consumeCtx, func(context.Context, []byte) error {
+		handled++
+		return nil
+	})
+	if handled != 2 {
+		t.Errorf("Esperadas duas ações publicadas, obtidas %d", handled)
+	}
+}
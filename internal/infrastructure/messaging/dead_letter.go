@@ -0,0 +1,56 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"project/internal/DTOs"
+)
+
+// DefaultMaxAttempts é quantas vezes o consumidor executa uma ação antes de desistir dela.
+const DefaultMaxAttempts = 5
+
+// ErrDeadLetterNotFound indica que não há mensagem morta com o id informado.
+var ErrDeadLetterNotFound = errors.New("mensagem não encontrada na fila de mensagens mortas")
+
+// DeadLetter é uma ação que esgotou as tentativas ou que o consumidor não conseguiu ler.
+type DeadLetter struct {
+	Id     string                 `json:"id"`
+	Action DTOs.PlaylistActionDTO `json:"action"`
+	// Body só é preenchido quando a mensagem não é um PlaylistActionDTO válido.
+	Body   string    `json:"body,omitempty"`
+	Reason string    `json:"reason"`
+	DeadAt time.Time `json:"dead_at"`
+}
+
+// DeadLetterQueueInterface inspeciona a fila de mensagens mortas. Replay devolve a ação à
+// fila principal com as tentativas zeradas; Discard a remove de vez.
+type DeadLetterQueueInterface interface {
+	List(ctx context.Context) ([]DeadLetter, error)
+	Replay(ctx context.Context, id string) error
+	ReplayAll(ctx context.Context) (int, error)
+	Discard(ctx context.Context, id string) error
+	DiscardAll(ctx context.Context) (int, error)
+}
+
+func newDeadLetter(id string, message []byte, reason string, deadAt time.Time) DeadLetter {
+	letter := DeadLetter{Id: id, Reason: reason, DeadAt: deadAt}
+	if err := json.Unmarshal(message, &letter.Action); err != nil {
+		letter.Body = string(message)
+	}
+	return letter
+}
+
+// replayMessage serializa a ação da mensagem morta como uma ação nova, sem tentativas nem espera.
+func replayMessage(letter DeadLetter) ([]byte, error) {
+	if letter.Body != "" {
+		return nil, fmt.Errorf("mensagem %s não é uma ação válida e não pode ser reprocessada", letter.Id)
+	}
+	action := letter.Action
+	action.Attempts = 0
+	action.RetryAt = 0
+	return json.Marshal(action)
+}
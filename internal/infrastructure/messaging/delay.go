@@ -0,0 +1,52 @@
+package messaging
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// delayBuckets são as esperas das filas de atraso, em ordem crescente. Cada fila segura as
+// mensagens pelo seu TTL e depois as devolve (dead-letter) à fila principal; esperas maiores
+// que o último bucket dão várias voltas até chegar em RetryAt.
+var delayBuckets = []time.Duration{
+	time.Second,
+	10 * time.Second,
+	time.Minute,
+	10 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+}
+
+func delayQueueName(queueName string, bucket time.Duration) string {
+	return fmt.Sprintf("%s.delay.%dms", queueName, bucket.Milliseconds())
+}
+
+// bucketFor escolhe o maior bucket que não passa da espera restante, para nunca entregar antes de RetryAt.
+func bucketFor(remaining time.Duration) time.Duration {
+	bucket := delayBuckets[0]
+	for _, candidate := range delayBuckets {
+		if candidate > remaining {
+			break
+		}
+		bucket = candidate
+	}
+	return bucket
+}
+
+// declareDelayQueues declara uma fila de atraso por bucket, sem consumidores, que devolve
+// as mensagens expiradas à fila queueName pelo exchange padrão.
+func declareDelayQueues(ch *amqp.Channel, queueName string) error {
+	for _, bucket := range delayBuckets {
+		args := amqp.Table{
+			"x-message-ttl":             bucket.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queueName,
+		}
+		if _, err := ch.QueueDeclare(delayQueueName(queueName, bucket), false, false, false, false, args); err != nil {
+			return fmt.Errorf("falha ao declarar fila de atraso %s: %v", delayQueueName(queueName, bucket), err)
+		}
+	}
+	return nil
+}
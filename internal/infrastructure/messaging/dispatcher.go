@@ -0,0 +1,123 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"project/internal/DTOs"
+	"project/internal/core/entities"
+	"project/internal/core/services"
+)
+
+// Erros permanentes: repetir não adianta, então a ação vai direto para a fila de mensagens mortas.
+var (
+	ErrUnknownAction = errors.New("ação desconhecida")
+	ErrInvalidAction = errors.New("ação inválida")
+)
+
+// ActionHandler executa uma ação enfileirada.
+type ActionHandler func(ctx context.Context, action DTOs.PlaylistActionDTO) error
+
+// Dispatcher associa cada nome de ação ao seu handler.
+type Dispatcher struct {
+	handlers map[string]ActionHandler
+}
+
+// NewDispatcher registra um handler para cada ação que o tratador de erros enfileira.
+func NewDispatcher(service services.YoutubePlaylistService) *Dispatcher {
+	d := &Dispatcher{handlers: make(map[string]ActionHandler)}
+
+	refreshPlaylists := func(ctx context.Context, action DTOs.PlaylistActionDTO) error {
+		_, err := service.GetAllPlaylists(ctx, action.UserId, true)
+		return err
+	}
+	d.Register("get_all_playlists", refreshPlaylists)
+	d.Register("save_all_playlists", refreshPlaylists)
+
+	refreshVideos := requirePlaylist(func(ctx context.Context, action DTOs.PlaylistActionDTO) error {
+		_, err := service.GetPlaylistVideos(ctx, action.PlaylistId, action.UserId, true)
+		return err
+	})
+	d.Register("get_playlist_videos", refreshVideos)
+	d.Register("get_playlist_video_ids", refreshVideos)
+
+	d.Register("delete_playlist", requirePlaylist(func(ctx context.Context, action DTOs.PlaylistActionDTO) error {
+		return service.DeletePlaylist(ctx, action.PlaylistId, action.UserId)
+	}))
+
+	d.Register("reorder_playlist", requirePlaylist(typed(func(ctx context.Context, action DTOs.PlaylistActionDTO, params DTOs.ReorderParamsDTO) error {
+		return service.ReorderPlaylist(ctx, action.PlaylistId, params.Criteria, action.UserId, params.Options.ToEntity())
+	})))
+
+	d.Register("get_video_details", typed(func(ctx context.Context, action DTOs.PlaylistActionDTO, params DTOs.VideoDetailsParamsDTO) error {
+		_, _, err := service.GetVideosDetails(ctx, params.VideoIds, action.UserId)
+		return err
+	}))
+
+	// A cópia é recriada a partir dos ids; os metadados vêm do cache compartilhado ou do YouTube.
+	d.Register("create_playlist", typed(func(ctx context.Context, action DTOs.PlaylistActionDTO, params DTOs.CreatePlaylistParamsDTO) error {
+		videos, _, err := service.GetVideosDetails(ctx, params.VideoIds, action.UserId)
+		if err != nil {
+			return err
+		}
+		source := entities.NewPlaylist(action.PlaylistId, params.ChannelId, params.Settings.Title, params.Settings.Description, time.Now(), videos)
+		_, err = service.CreateNewPlaylist(ctx, source, params.Settings.ToEntity(), action.UserId)
+		return err
+	}))
+
+	d.Register("add_video_to_playlist", requirePlaylist(typed(func(ctx context.Context, action DTOs.PlaylistActionDTO, params DTOs.AddVideoParamsDTO) error {
+		return service.AddVideoToPlaylist(ctx, action.PlaylistId, params.VideoId, action.UserId)
+	})))
+
+	d.Register("update_playlist", requirePlaylist(typed(func(ctx context.Context, action DTOs.PlaylistActionDTO, params DTOs.UpdatePlaylistParamsDTO) error {
+		return service.UpdatePlaylist(ctx, action.PlaylistId, params.Settings.ToEntity(), action.UserId)
+	})))
+
+	return d
+}
+
+// Register associa name a handler, substituindo o anterior.
+func (d *Dispatcher) Register(name string, handler ActionHandler) {
+	d.handlers[name] = handler
+}
+
+// Dispatch executa o handler da ação. Toda ação pertence a um usuário, cujo token é usado nas chamadas.
+func (d *Dispatcher) Dispatch(ctx context.Context, action DTOs.PlaylistActionDTO) error {
+	handler, ok := d.handlers[action.ActionName]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownAction, action.ActionName)
+	}
+	if action.UserId == "" {
+		return fmt.Errorf("%w: %s sem usuário", ErrInvalidAction, action.ActionName)
+	}
+	return handler(ctx, action)
+}
+
+// typed decodifica e valida os parâmetros da ação antes de chamar fn.
+func typed[P interface{ Validate() error }](fn func(ctx context.Context, action DTOs.PlaylistActionDTO, params P) error) ActionHandler {
+	return func(ctx context.Context, action DTOs.PlaylistActionDTO) error {
+		var params P
+		if len(action.Params) > 0 {
+			if err := json.Unmarshal(action.Params, &params); err != nil {
+				return fmt.Errorf("%w: parâmetros de %s: %v", ErrInvalidAction, action.ActionName, err)
+			}
+		}
+		if err := params.Validate(); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidAction, action.ActionName, err)
+		}
+		return fn(ctx, action, params)
+	}
+}
+
+// requirePlaylist rejeita as ações sobre uma playlist que chegam sem o id dela.
+func requirePlaylist(handler ActionHandler) ActionHandler {
+	return func(ctx context.Context, action DTOs.PlaylistActionDTO) error {
+		if action.PlaylistId == "" {
+			return fmt.Errorf("%w: %s sem playlist", ErrInvalidAction, action.ActionName)
+		}
+		return handler(ctx, action)
+	}
+}
@@ -0,0 +1,67 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"project/internal/DTOs"
+	"project/internal/infrastructure/cache"
+)
+
+// DefaultCompletedTTL é por quanto tempo uma ação concluída é lembrada; cobre com folga a
+// maior espera entre as retentativas.
+const DefaultCompletedTTL = 24 * time.Hour
+
+// CompletedStoreInterface lembra as ações já concluídas, pela chave de idempotência, para
+// que a reentrega de uma ação que terminou não a execute de novo.
+type CompletedStoreInterface interface {
+	Completed(ctx context.Context, key string) (bool, error)
+	MarkCompleted(ctx context.Context, key string) error
+}
+
+type completedStore struct {
+	client cache.RedisCacheInterface
+	ttl    time.Duration
+}
+
+// NewCompletedStore guarda as chaves no cache por ttl (DefaultCompletedTTL quando zero).
+func NewCompletedStore(client cache.RedisCacheInterface, ttl time.Duration) CompletedStoreInterface {
+	if ttl <= 0 {
+		ttl = DefaultCompletedTTL
+	}
+	return &completedStore{client: client, ttl: ttl}
+}
+
+func (s *completedStore) Completed(ctx context.Context, key string) (bool, error) {
+	if _, err := s.client.Get(ctx, completedKey(key)); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *completedStore) MarkCompleted(ctx context.Context, key string) error {
+	return s.client.Set(ctx, completedKey(key), time.Now().UTC().Format(time.RFC3339), s.ttl)
+}
+
+func completedKey(key string) string {
+	return "completed_actions:" + key
+}
+
+// idempotencyKey identifica a ação entre reentregas. A chave do cliente vale para a
+// requisição inteira, que pode adiar várias ações, então usuário, ação e playlist entram junto.
+// Sem chave nem job, a ação não é deduplicada.
+func idempotencyKey(action DTOs.PlaylistActionDTO) string {
+	key := action.IdempotencyKey
+	if key == "" {
+		key = action.JobId
+	}
+	if key == "" {
+		return ""
+	}
+	return key + ":" + action.UserId + ":" + action.ActionName + ":" + action.PlaylistId
+}
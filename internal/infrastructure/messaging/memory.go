@@ -0,0 +1,189 @@
+package messaging
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type memoryJob struct {
+	message []byte
+	at      time.Time
+}
+
+type memoryQueue struct {
+	mu      sync.Mutex
+	pending []memoryJob
+	dead    []DeadLetter
+	// wake acorda os consumidores quando chega uma mensagem nova.
+	wake chan struct{}
+}
+
+// NewMemoryQueue cria uma fila que vive no próprio processo, para testes e instalações de um
+// só nó. As mensagens se perdem quando o processo termina.
+func NewMemoryQueue() JobQueueInterface {
+	return &memoryQueue{wake: make(chan struct{}, 1)}
+}
+
+func (q *memoryQueue) Publish(ctx context.Context, message []byte, at time.Time) error {
+	q.push(message, at)
+	return nil
+}
+
+// push mantém as mensagens pendentes ordenadas pelo instante de entrega.
+func (q *memoryQueue) push(message []byte, at time.Time) {
+	q.mu.Lock()
+	i := sort.Search(len(q.pending), func(i int) bool { return q.pending[i].at.After(at) })
+	q.pending = append(q.pending, memoryJob{})
+	copy(q.pending[i+1:], q.pending[i:])
+	q.pending[i] = memoryJob{message: message, at: at}
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next retira a primeira mensagem já vencida ou informa quanto falta para a próxima (zero se vazia).
+func (q *memoryQueue) next() ([]byte, time.Duration, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return nil, 0, false
+	}
+	if wait := time.Until(q.pending[0].at); wait > 0 {
+		return nil, wait, false
+	}
+	job := q.pending[0]
+	q.pending = q.pending[1:]
+	return job.message, 0, true
+}
+
+// Consume devolve à fila, para entrega imediata, as mensagens cujo handle falhou.
+func (q *memoryQueue) Consume(ctx context.Context, handle HandlerFunc) error {
+	for {
+		message, wait, ok := q.next()
+		if ok {
+			if err := handle(ctx, message); err != nil {
+				q.push(message, time.Now())
+			}
+			continue
+		}
+
+		// Espera a próxima mensagem vencer ou uma publicação nova; com a fila vazia, só a publicação.
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+		select {
+		case <-ctx.Done():
+		case <-q.wake:
+		case <-expired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (q *memoryQueue) DeadLetter(ctx context.Context, message []byte, reason string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.dead = append(q.dead, newDeadLetter(uuid.NewString(), message, reason, time.Now()))
+	return nil
+}
+
+func (q *memoryQueue) List(ctx context.Context) ([]DeadLetter, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]DeadLetter(nil), q.dead...), nil
+}
+
+func (q *memoryQueue) Replay(ctx context.Context, id string) error {
+	letter, ok := q.take(id)
+	if !ok {
+		return ErrDeadLetterNotFound
+	}
+	if err := q.replay(letter); err != nil {
+		q.restore(letter)
+		return err
+	}
+	return nil
+}
+
+func (q *memoryQueue) ReplayAll(ctx context.Context) (int, error) {
+	letters := q.takeAll()
+	for i, letter := range letters {
+		if err := q.replay(letter); err != nil {
+			// A que falhou e as seguintes continuam mortas, como no RabbitMQ.
+			q.restore(letters[i:]...)
+			return i, err
+		}
+	}
+	return len(letters), nil
+}
+
+func (q *memoryQueue) Discard(ctx context.Context, id string) error {
+	if _, ok := q.take(id); !ok {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+func (q *memoryQueue) DiscardAll(ctx context.Context) (int, error) {
+	return len(q.takeAll()), nil
+}
+
+func (q *memoryQueue) replay(letter DeadLetter) error {
+	message, err := replayMessage(letter)
+	if err != nil {
+		return err
+	}
+	q.push(message, time.Now())
+	return nil
+}
+
+func (q *memoryQueue) restore(letters ...DeadLetter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.dead = append(letters, q.dead...)
+}
+
+func (q *memoryQueue) take(id string) (DeadLetter, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, letter := range q.dead {
+		if letter.Id == id {
+			q.dead = append(q.dead[:i], q.dead[i+1:]...)
+			return letter, true
+		}
+	}
+	return DeadLetter{}, false
+}
+
+func (q *memoryQueue) takeAll() []DeadLetter {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	letters := q.dead
+	q.dead = nil
+	return letters
+}
+
+func (q *memoryQueue) Close() error {
+	return nil
+}
@@ -0,0 +1,214 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"project/internal/DTOs"
+	"project/internal/core/entities"
+	"project/internal/core/services"
+	"project/internal/infrastructure/cache"
+	"project/internal/infrastructure/messaging"
+	"project/internal/infrastructure/retry"
+)
+
+type failingService struct {
+	services.YoutubePlaylistService
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *failingService) ReorderPlaylist(ctx context.Context, playlistId, criteria, userId string, options entities.PlaylistOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return errors.New("falha permanente")
+}
+
+func (s *failingService) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func TestMemoryQueueDeliversWhenDue(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	queue := messaging.NewMemoryQueue()
+
+	queue.Publish(ctx, []byte("depois"), time.Now().Add(100*time.Millisecond))
+	queue.Publish(ctx, []byte("agora"), time.Time{})
+
+	received := make(chan string, 2)
+	start := time.Now()
+	go queue.Consume(ctx, func(_ context.Context, message []byte) error {
+		received <- string(message)
+		return nil
+	})
+
+	if first := <-received; first != "agora" {
+		t.Fatalf("Esperado 'agora' primeiro, obtido %q", first)
+	}
+	if second := <-received; second != "depois" {
+		t.Fatalf("Esperado 'depois' em seguida, obtido %q", second)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("A mensagem agendada foi entregue antes da hora: %v", elapsed)
+	}
+}
+
+func TestConsumerDeadLettersAfterMaxAttempts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	queue := messaging.NewMemoryQueue()
+	service := &failingService{}
+
+	consumer := messaging.NewConsumer(messaging.NewDispatcher(service), queue, 3)
+	consumer.Redelivery = retry.Policy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	go consumer.Start(ctx)
+
+	message, _ := json.Marshal(DTOs.PlaylistActionDTO{
+		ActionName: "reorder_playlist",
+		PlaylistId: "playlist1",
+		UserId:     "user123",
+		Params:     json.RawMessage(`{"criteria":"byTitle"}`),
+	})
+	queue.Publish(ctx, message, time.Time{})
+
+	var letters []messaging.DeadLetter
+	for len(letters) == 0 && ctx.Err() == nil {
+		time.Sleep(10 * time.Millisecond)
+		letters, _ = queue.List(ctx)
+	}
+	if len(letters) != 1 {
+		t.Fatalf("Esperada 1 mensagem morta, obtido %d", len(letters))
+	}
+	if service.Calls() != 3 || letters[0].Action.Attempts != 3 || letters[0].Action.Err != "falha permanente" {
+		t.Fatalf("A ação deveria morrer depois de 3 tentativas com o último erro, obtido %d chamadas e %+v", service.Calls(), letters[0])
+	}
+
+	if err := queue.Replay(ctx, letters[0].Id); err != nil {
+		t.Fatalf("Erro ao reprocessar: %v", err)
+	}
+	for service.Calls() < 4 && ctx.Err() == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if service.Calls() < 4 {
+		t.Error("A ação reprocessada deveria voltar a ser executada")
+	}
+
+	if err := queue.Discard(ctx, "inexistente"); !errors.Is(err, messaging.ErrDeadLetterNotFound) {
+		t.Errorf("Esperado ErrDeadLetterNotFound, obtido %v", err)
+	}
+}
+
+func TestConsumerDeadLettersUnknownAndInvalidActions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	queue := messaging.NewMemoryQueue()
+	service := &failingService{}
+	go messaging.NewConsumer(messaging.NewDispatcher(service), queue, 3).Start(ctx)
+
+	unknown, _ := json.Marshal(DTOs.PlaylistActionDTO{ActionName: "rename_channel", UserId: "user123"})
+	withoutCriteria, _ := json.Marshal(DTOs.PlaylistActionDTO{ActionName: "reorder_playlist", PlaylistId: "playlist1", UserId: "user123"})
+	queue.Publish(ctx, unknown, time.Time{})
+	queue.Publish(ctx, withoutCriteria, time.Time{})
+
+	var letters []messaging.DeadLetter
+	for len(letters) < 2 && ctx.Err() == nil {
+		time.Sleep(10 * time.Millisecond)
+		letters, _ = queue.List(ctx)
+	}
+	if len(letters) != 2 {
+		t.Fatalf("Esperadas 2 mensagens mortas, obtido %d", len(letters))
+	}
+	for _, letter := range letters {
+		if letter.Action.Attempts != 0 || letter.Reason == "" {
+			t.Errorf("Ações desconhecidas ou inválidas deveriam morrer sem tentativas e com o motivo: %+v", letter)
+		}
+	}
+	if service.Calls() != 0 {
+		t.Errorf("O serviço não deveria ser chamado, obtido %d chamadas", service.Calls())
+	}
+}
+
+type incompleteCopyService struct {
+	failingService
+}
+
+func (s *incompleteCopyService) ReorderPlaylist(ctx context.Context, playlistId, criteria, userId string, options entities.PlaylistOptions) error {
+	s.failingService.ReorderPlaylist(ctx, playlistId, criteria, userId, options)
+	return fmt.Errorf("erro inesperado: %w", services.ErrIncompleteCopy)
+}
+
+func TestConsumerDeadLettersPermanentFailures(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	queue := messaging.NewMemoryQueue()
+	service := &incompleteCopyService{}
+	go messaging.NewConsumer(messaging.NewDispatcher(service), queue, 3).Start(ctx)
+
+	message, _ := json.Marshal(DTOs.PlaylistActionDTO{
+		ActionName: "reorder_playlist",
+		PlaylistId: "playlist1",
+		UserId:     "user123",
+		Params:     json.RawMessage(`{"criteria":"byTitle"}`),
+	})
+	queue.Publish(ctx, message, time.Time{})
+
+	var letters []messaging.DeadLetter
+	for len(letters) == 0 && ctx.Err() == nil {
+		time.Sleep(10 * time.Millisecond)
+		letters, _ = queue.List(ctx)
+	}
+	if len(letters) != 1 || service.Calls() != 1 {
+		t.Fatalf("A cópia incompleta deveria morrer na primeira tentativa, obtido %d mensagens e %d chamadas", len(letters), service.Calls())
+	}
+}
+
+type succeedingService struct {
+	failingService
+}
+
+func (s *succeedingService) ReorderPlaylist(ctx context.Context, playlistId, criteria, userId string, options entities.PlaylistOptions) error {
+	s.failingService.ReorderPlaylist(ctx, playlistId, criteria, userId, options)
+	return nil
+}
+
+func TestConsumerSkipsCompletedActions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	queue := messaging.NewMemoryQueue()
+	service := &succeedingService{}
+	consumer := messaging.NewConsumer(messaging.NewDispatcher(service), queue, 3)
+	consumer.Completed = messaging.NewCompletedStore(cache.NewMemoryCache(0), 0)
+	go consumer.Start(ctx)
+
+	action := DTOs.PlaylistActionDTO{
+		ActionName:     "reorder_playlist",
+		PlaylistId:     "playlist1",
+		UserId:         "user123",
+		Params:         json.RawMessage(`{"criteria":"byTitle"}`),
+		IdempotencyKey: "key1",
+	}
+	message, _ := json.Marshal(action)
+	queue.Publish(ctx, message, time.Time{})
+	queue.Publish(ctx, message, time.Time{})
+	// A mesma chave em outra playlist é outra ação e precisa ser executada.
+	action.PlaylistId = "playlist2"
+	other, _ := json.Marshal(action)
+	queue.Publish(ctx, other, time.Time{})
+
+	for service.Calls() < 2 && ctx.Err() == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if service.Calls() != 2 {
+		t.Fatalf("A reentrega de uma ação concluída deveria ser ignorada, obtido %d chamadas", service.Calls())
+	}
+}
@@ -0,0 +1,212 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"project/internal/DTOs"
+	"project/internal/infrastructure/logging"
+)
+
+// postgresPollInterval é a espera do consumidor quando não há mensagem vencida.
+const postgresPollInterval = time.Second
+
+// postgresLease é por quanto tempo uma mensagem reservada fica invisível aos outros
+// consumidores. Se o processo morrer no meio do handler, ela volta à fila depois disso.
+const postgresLease = 10 * time.Minute
+
+type postgresQueue struct {
+	db   *gorm.DB
+	name string
+}
+
+// NewPostgresQueue guarda as mensagens na tabela queued_jobs do banco da aplicação. Cada
+// consumidor reserva a mensagem que vai processar adiando seu run_at por postgresLease, com
+// FOR UPDATE SKIP LOCKED, então várias réplicas podem consumir a mesma fila sem entregar a
+// mesma mensagem duas vezes e sem manter uma transação aberta durante o handler.
+func NewPostgresQueue(db *gorm.DB, queueName string) (JobQueueInterface, error) {
+	if db == nil {
+		return nil, errors.New("a fila no Postgres precisa da conexão com o banco")
+	}
+	if err := db.AutoMigrate(&DTOs.QueuedJobDTO{}); err != nil {
+		return nil, fmt.Errorf("falha ao criar a tabela da fila: %v", err)
+	}
+	return &postgresQueue{db: db, name: queueName}, nil
+}
+
+func (q *postgresQueue) Publish(ctx context.Context, message []byte, at time.Time) error {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	job := DTOs.QueuedJobDTO{ID: uuid.NewString(), Queue: q.name, RunAt: at, Body: message}
+	if err := q.db.WithContext(ctx).Create(&job).Error; err != nil {
+		return fmt.Errorf("falha ao publicar mensagem: %v", err)
+	}
+	return nil
+}
+
+// Consume só remove a mensagem quando handle termina sem erro; caso contrário ela volta a
+// ficar disponível imediatamente.
+func (q *postgresQueue) Consume(ctx context.Context, handle HandlerFunc) error {
+	for {
+		found, err := q.consumeOne(ctx, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			logging.Error("Erro ao consumir a fila do Postgres", zap.String("queue", q.name), zap.Error(err))
+		}
+		if found && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(postgresPollInterval):
+		}
+	}
+}
+
+func (q *postgresQueue) consumeOne(ctx context.Context, handle HandlerFunc) (bool, error) {
+	job, found, err := q.claim(ctx)
+	if err != nil || !found {
+		return false, err
+	}
+
+	// A confirmação não depende de ctx: uma ação que terminou não deve voltar à fila só
+	// porque o consumidor está sendo encerrado.
+	settle := q.db.WithContext(context.WithoutCancel(ctx)).
+		Model(&DTOs.QueuedJobDTO{}).
+		Where("id = ? AND run_at = ?", job.ID, job.RunAt)
+
+	if err := handle(ctx, job.Body); err != nil {
+		if rescheduleErr := settle.Update("run_at", time.Now()).Error; rescheduleErr != nil {
+			logging.Error("Erro ao devolver mensagem à fila do Postgres", zap.String("id", job.ID), zap.Error(rescheduleErr))
+		}
+		return true, err
+	}
+	return true, settle.Delete(&DTOs.QueuedJobDTO{}).Error
+}
+
+// claim reserva a próxima mensagem vencida em uma transação curta. O run_at devolvido é o
+// fim da reserva; a confirmação o compara para não mexer em uma mensagem que, com a reserva
+// vencida, já foi entregue a outro consumidor.
+func (q *postgresQueue) claim(ctx context.Context) (DTOs.QueuedJobDTO, bool, error) {
+	now := time.Now()
+	leaseUntil := now.Add(postgresLease).Truncate(time.Microsecond)
+
+	var job DTOs.QueuedJobDTO
+	result := q.db.WithContext(ctx).Raw(`
+		UPDATE queued_jobs SET run_at = ?
+		WHERE id = (
+			SELECT id FROM queued_jobs
+			WHERE queue = ? AND dead_at IS NULL AND run_at <= ?
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, leaseUntil, q.name, now).Scan(&job)
+	if result.Error != nil {
+		return job, false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return job, false, nil
+	}
+	job.RunAt = leaseUntil
+	return job, true, nil
+}
+
+func (q *postgresQueue) DeadLetter(ctx context.Context, message []byte, reason string) error {
+	now := time.Now()
+	job := DTOs.QueuedJobDTO{ID: uuid.NewString(), Queue: q.name, RunAt: now, Body: message, DeadAt: &now, Reason: reason}
+	if err := q.db.WithContext(ctx).Create(&job).Error; err != nil {
+		return fmt.Errorf("falha ao publicar mensagem morta: %v", err)
+	}
+	return nil
+}
+
+func (q *postgresQueue) dead(ctx context.Context) *gorm.DB {
+	return q.db.WithContext(ctx).Where("queue = ? AND dead_at IS NOT NULL", q.name)
+}
+
+func (q *postgresQueue) List(ctx context.Context) ([]DeadLetter, error) {
+	var jobs []DTOs.QueuedJobDTO
+	if err := q.dead(ctx).Order("dead_at").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	letters := make([]DeadLetter, len(jobs))
+	for i, job := range jobs {
+		letters[i] = newDeadLetter(job.ID, job.Body, job.Reason, *job.DeadAt)
+	}
+	return letters, nil
+}
+
+// Replay devolve a mensagem à fila na mesma linha, com a ação reescrita como nova.
+func (q *postgresQueue) Replay(ctx context.Context, id string) error {
+	return q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var job DTOs.QueuedJobDTO
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND queue = ? AND dead_at IS NOT NULL", id, q.name).
+			Limit(1).
+			Find(&job)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDeadLetterNotFound
+		}
+
+		body, err := replayMessage(newDeadLetter(job.ID, job.Body, job.Reason, *job.DeadAt))
+		if err != nil {
+			return err
+		}
+		return tx.Model(&job).Updates(map[string]any{
+			"body":    body,
+			"run_at":  time.Now(),
+			"dead_at": nil,
+			"reason":  "",
+		}).Error
+	})
+}
+
+func (q *postgresQueue) ReplayAll(ctx context.Context) (int, error) {
+	letters, err := q.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for i, letter := range letters {
+		if err := q.Replay(ctx, letter.Id); err != nil {
+			return i, err
+		}
+	}
+	return len(letters), nil
+}
+
+func (q *postgresQueue) Discard(ctx context.Context, id string) error {
+	result := q.dead(ctx).Where("id = ?", id).Delete(&DTOs.QueuedJobDTO{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+func (q *postgresQueue) DiscardAll(ctx context.Context) (int, error) {
+	result := q.dead(ctx).Delete(&DTOs.QueuedJobDTO{})
+	return int(result.RowsAffected), result.Error
+}
+
+// Close não fecha o banco, que pertence à aplicação.
+func (q *postgresQueue) Close() error {
+	return nil
+}
@@ -0,0 +1,65 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Backends de fila aceitos por New.
+const (
+	BackendRabbitMQ = "rabbitmq"
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+)
+
+// DefaultQueueName é a fila usada quando Options.Queue fica vazio.
+const DefaultQueueName = "reorderApi"
+
+// HandlerFunc processa uma mensagem. Se retornar erro, a mensagem é entregue de novo.
+type HandlerFunc func(ctx context.Context, message []byte) error
+
+// JobQueueInterface é o transporte das ações enfileiradas. Os backends só entregam e guardam
+// mensagens; tentativas, esperas e a decisão de matar uma mensagem ficam no Consumer, para
+// que todos tenham a mesma semântica de retentativa.
+type JobQueueInterface interface {
+	// Publish entrega message a partir de at; imediatamente quando at já passou.
+	Publish(ctx context.Context, message []byte, at time.Time) error
+	// Consume entrega as mensagens a handle, uma por vez, até ctx ser cancelado.
+	Consume(ctx context.Context, handle HandlerFunc) error
+	// DeadLetter guarda message na fila de mensagens mortas com o motivo.
+	DeadLetter(ctx context.Context, message []byte, reason string) error
+	DeadLetterQueueInterface
+	Close() error
+}
+
+// Options escolhe e configura o backend da fila.
+type Options struct {
+	// Backend é "rabbitmq" (padrão), "memory" ou "postgres".
+	Backend string
+	// AMQPURL é obrigatório com o RabbitMQ.
+	AMQPURL string
+	Queue   string
+	// DB é a conexão usada pelo backend Postgres.
+	DB *gorm.DB
+}
+
+// New cria a fila do backend escolhido em options.
+func New(options Options) (JobQueueInterface, error) {
+	if options.Queue == "" {
+		options.Queue = DefaultQueueName
+	}
+
+	switch options.Backend {
+	case "", BackendRabbitMQ:
+		return NewRabbitMQQueue(options.AMQPURL, options.Queue)
+	case BackendMemory:
+		return NewMemoryQueue(), nil
+	case BackendPostgres:
+		return NewPostgresQueue(options.DB, options.Queue)
+	default:
+		return nil, fmt.Errorf("backend de fila desconhecido: %q", options.Backend)
+	}
+}
@@ -0,0 +1,213 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+	"project/internal/infrastructure/logging"
+)
+
+const lastErrorHeader = "x-last-error"
+
+// Espera entre as tentativas de reconexão do consumidor.
+const (
+	reconnectBaseDelay = time.Second
+	reconnectMaxDelay  = 30 * time.Second
+)
+
+type rabbitmqQueue struct {
+	url  string
+	name string
+
+	mu   sync.Mutex
+	conn *amqp.Connection
+	// ch publica e inspeciona as mensagens mortas; o consumo usa uma conexão própria.
+	ch *amqp.Channel
+
+	// inspecting serializa as varreduras da fila de mensagens mortas.
+	inspecting sync.Mutex
+}
+
+// NewRabbitMQQueue cria a fila queueName no RabbitMQ em url. O RabbitMQ fora do ar não impede
+// a criação: a conexão é refeita na próxima publicação e o consumo espera ele voltar.
+func NewRabbitMQQueue(url, queueName string) (JobQueueInterface, error) {
+	if url == "" {
+		return nil, errors.New("AMQP_URL não configurada")
+	}
+
+	q := &rabbitmqQueue{url: url, name: queueName}
+	if _, err := q.channel(); err != nil {
+		logging.Error("RabbitMQ indisponível, a conexão será refeita quando necessária", zap.Error(err))
+	}
+	return q, nil
+}
+
+// channel retorna o canal de publicação, reconectando se a conexão caiu.
+func (q *rabbitmqQueue) channel() (*amqp.Channel, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.conn != nil && !q.conn.IsClosed() {
+		return q.ch, nil
+	}
+	conn, ch, err := q.dial()
+	if err != nil {
+		return nil, err
+	}
+	q.conn, q.ch = conn, ch
+	return ch, nil
+}
+
+// resetChannel descarta o canal de publicação depois de um erro, para que a próxima chamada reconecte.
+func (q *rabbitmqQueue) resetChannel() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.conn != nil {
+		q.conn.Close()
+	}
+	q.conn, q.ch = nil, nil
+}
+
+// dial abre uma conexão e um canal e declara a fila, as filas de atraso e a de mensagens mortas.
+func (q *rabbitmqQueue) dial() (*amqp.Connection, *amqp.Channel, error) {
+	conn, err := amqp.Dial(q.url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("falha ao conectar ao RabbitMQ: %v", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("falha ao abrir canal: %v", err)
+	}
+
+	if err := q.declare(ch); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, ch, nil
+}
+
+func (q *rabbitmqQueue) declare(ch *amqp.Channel) error {
+	if _, err := ch.QueueDeclare(q.name, false, false, false, false, nil); err != nil {
+		return fmt.Errorf("falha ao declarar fila: %v", err)
+	}
+	if err := declareDelayQueues(ch, q.name); err != nil {
+		return err
+	}
+	return declareDeadLetterQueue(ch, q.name)
+}
+
+// Publish publica direto na fila quando at já passou; senão, na maior fila de atraso que
+// não ultrapassa at. O Consumer devolve às filas de atraso o que ainda chegar adiantado.
+func (q *rabbitmqQueue) Publish(ctx context.Context, message []byte, at time.Time) error {
+	routingKey := q.name
+	if remaining := time.Until(at); remaining > 0 {
+		routingKey = delayQueueName(q.name, bucketFor(remaining))
+	}
+	return q.publish("", routingKey, amqp.Publishing{
+		ContentType: "text/plain",
+		Body:        message,
+	})
+}
+
+func (q *rabbitmqQueue) DeadLetter(ctx context.Context, message []byte, reason string) error {
+	return q.publish(deadLetterExchange(q.name), q.name, amqp.Publishing{
+		ContentType: "text/plain",
+		MessageId:   uuid.NewString(),
+		Timestamp:   time.Now(),
+		Headers:     amqp.Table{lastErrorHeader: reason},
+		Body:        message,
+	})
+}
+
+// publish tenta de novo uma vez, em uma conexão nova, se o canal tiver sido fechado.
+func (q *rabbitmqQueue) publish(exchange, routingKey string, msg amqp.Publishing) error {
+	var err error
+	for range 2 {
+		var ch *amqp.Channel
+		ch, err = q.channel()
+		if err != nil {
+			return err
+		}
+		err = ch.Publish(exchange, routingKey, false, false, msg)
+		if err == nil {
+			return nil
+		}
+		q.resetChannel()
+	}
+	return fmt.Errorf("falha ao publicar mensagem: %v", err)
+}
+
+// Consume processa a fila até ctx ser cancelado, reconectando com backoff quando a conexão cai.
+// Mensagens cujo handle falhou voltam à fila.
+func (q *rabbitmqQueue) Consume(ctx context.Context, handle HandlerFunc) error {
+	delay := reconnectBaseDelay
+	for {
+		err := q.consume(ctx, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			// A conexão funcionou e caiu depois: a próxima espera recomeça do início.
+			delay = reconnectBaseDelay
+		}
+		logging.Error("Consumidor do RabbitMQ desconectado, reconectando", zap.Duration("delay", delay), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, reconnectMaxDelay)
+	}
+}
+
+// consume retorna nil quando a entrega termina porque a conexão caiu.
+func (q *rabbitmqQueue) consume(ctx context.Context, handle HandlerFunc) error {
+	conn, ch, err := q.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	msgs, err := ch.Consume(q.name, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("falha ao registrar consumidor: %v", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case d, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			if err := handle(ctx, d.Body); err != nil {
+				d.Nack(false, true)
+				continue
+			}
+			d.Ack(false)
+		}
+	}
+}
+
+func (q *rabbitmqQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.conn == nil {
+		return nil
+	}
+	err := q.conn.Close()
+	q.conn, q.ch = nil, nil
+	return err
+}
@@ -0,0 +1,145 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streadway/amqp"
+)
+
+func deadLetterExchange(queueName string) string {
+	return queueName + ".dlx"
+}
+
+func deadLetterQueueName(queueName string) string {
+	return queueName + ".dead"
+}
+
+// declareDeadLetterQueue declara o exchange de mensagens mortas e a fila ligada a ele.
+func declareDeadLetterQueue(ch *amqp.Channel, queueName string) error {
+	exchange := deadLetterExchange(queueName)
+	if err := ch.ExchangeDeclare(exchange, "direct", false, false, false, false, nil); err != nil {
+		return fmt.Errorf("falha ao declarar exchange %s: %v", exchange, err)
+	}
+	dead := deadLetterQueueName(queueName)
+	if _, err := ch.QueueDeclare(dead, false, false, false, false, nil); err != nil {
+		return fmt.Errorf("falha ao declarar fila %s: %v", dead, err)
+	}
+	if err := ch.QueueBind(dead, queueName, exchange, false, nil); err != nil {
+		return fmt.Errorf("falha ao ligar fila %s: %v", dead, err)
+	}
+	return nil
+}
+
+type verdict int
+
+const (
+	keep verdict = iota
+	replay
+	discard
+)
+
+func (q *rabbitmqQueue) List(ctx context.Context) ([]DeadLetter, error) {
+	var letters []DeadLetter
+	_, err := q.each(ctx, func(letter DeadLetter) verdict {
+		letters = append(letters, letter)
+		return keep
+	})
+	return letters, err
+}
+
+func (q *rabbitmqQueue) Replay(ctx context.Context, id string) error {
+	return q.one(ctx, id, replay)
+}
+
+func (q *rabbitmqQueue) ReplayAll(ctx context.Context) (int, error) {
+	return q.each(ctx, func(DeadLetter) verdict { return replay })
+}
+
+func (q *rabbitmqQueue) Discard(ctx context.Context, id string) error {
+	return q.one(ctx, id, discard)
+}
+
+func (q *rabbitmqQueue) DiscardAll(ctx context.Context) (int, error) {
+	return q.each(ctx, func(DeadLetter) verdict { return discard })
+}
+
+func (q *rabbitmqQueue) one(ctx context.Context, id string, v verdict) error {
+	n, err := q.each(ctx, func(letter DeadLetter) verdict {
+		if letter.Id == id {
+			return v
+		}
+		return keep
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+// each percorre a fila de mensagens mortas sem confirmá-las e aplica o destino escolhido por
+// fn a cada uma. As mantidas voltam à fila ao final; retorna quantas foram reprocessadas ou removidas.
+func (q *rabbitmqQueue) each(ctx context.Context, fn func(DeadLetter) verdict) (int, error) {
+	q.inspecting.Lock()
+	defer q.inspecting.Unlock()
+
+	ch, err := q.channel()
+	if err != nil {
+		return 0, err
+	}
+
+	var kept []amqp.Delivery
+	defer func() {
+		for _, d := range kept {
+			d.Nack(false, true)
+		}
+	}()
+
+	affected := 0
+	for ctx.Err() == nil {
+		d, ok, err := ch.Get(deadLetterQueueName(q.name), false)
+		if err != nil {
+			return affected, fmt.Errorf("falha ao ler fila de mensagens mortas: %v", err)
+		}
+		if !ok {
+			return affected, nil
+		}
+
+		reason, _ := d.Headers[lastErrorHeader].(string)
+		letter := newDeadLetter(d.MessageId, d.Body, reason, d.Timestamp)
+		switch fn(letter) {
+		case replay:
+			if err := q.replay(ch, letter); err != nil {
+				kept = append(kept, d)
+				return affected, err
+			}
+			d.Ack(false)
+			affected++
+		case discard:
+			d.Ack(false)
+			affected++
+		default:
+			kept = append(kept, d)
+		}
+	}
+	return affected, ctx.Err()
+}
+
+// replay publica a ação de novo na fila principal, como uma ação nova.
+func (q *rabbitmqQueue) replay(ch *amqp.Channel, letter DeadLetter) error {
+	body, err := replayMessage(letter)
+	if err != nil {
+		return err
+	}
+	err = ch.Publish("", q.name, false, false, amqp.Publishing{
+		ContentType: "text/plain",
+		Body:        body,
+	})
+	if err != nil {
+		return fmt.Errorf("falha ao reprocessar mensagem %s: %v", letter.Id, err)
+	}
+	return nil
+}
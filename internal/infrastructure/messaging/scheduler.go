@@ -0,0 +1,91 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"project/internal/DTOs"
+	coreErrors "project/internal/core/errors"
+	"project/internal/core/ports"
+	"project/internal/infrastructure/jobs"
+	"project/internal/infrastructure/requestctx"
+)
+
+type jobScheduler struct {
+	queue JobQueueInterface
+	store jobs.StoreInterface
+}
+
+// NewJobScheduler agenda os jobs do núcleo: o estado vai para store e a ação para queue.
+func NewJobScheduler(queue JobQueueInterface, store jobs.StoreInterface) ports.JobSchedulerInterface {
+	return &jobScheduler{queue: queue, store: store}
+}
+
+func (s *jobScheduler) Schedule(ctx context.Context, job coreErrors.Job) (string, error) {
+	if job.RequestId == "" {
+		job.RequestId = requestctx.RequestId(ctx)
+	}
+	if job.IdempotencyKey == "" {
+		job.IdempotencyKey = requestctx.IdempotencyKey(ctx)
+	}
+
+	action := DTOs.PlaylistActionDTO{
+		ActionName:     job.Action,
+		PlaylistId:     job.PlaylistId,
+		UserId:         job.UserId,
+		RequestId:      job.RequestId,
+		IdempotencyKey: job.IdempotencyKey,
+		JobId:          job.JobId,
+	}
+	if job.Params != nil {
+		params, err := json.Marshal(job.Params)
+		if err != nil {
+			return "", err
+		}
+		action.Params = params
+	}
+	message, err := json.Marshal(action)
+	if err != nil {
+		return "", err
+	}
+
+	// Um pedido repetido com a mesma chave aponta para o job original em vez de criar outro.
+	if existing, ok, err := s.existing(ctx, job); ok || err != nil {
+		return existing, err
+	}
+
+	// O job existe antes da mensagem, para que o consumidor sempre encontre o que atualizar.
+	status := jobs.Status{Id: job.JobId, UserId: job.UserId, Action: job.Action, PlaylistId: job.PlaylistId, IdempotencyKey: job.IdempotencyKey}
+	if err := s.store.Create(ctx, status); err != nil {
+		// Outra requisição com a mesma chave criou o job entre a consulta e o Create.
+		if errors.Is(err, jobs.ErrDuplicateJob) {
+			if existing, ok, findErr := s.existing(ctx, job); ok || findErr != nil {
+				return existing, findErr
+			}
+		}
+		return "", fmt.Errorf("erro ao registrar o job: %w", err)
+	}
+	if err := s.queue.Publish(ctx, message, time.Time{}); err != nil {
+		s.store.SetState(context.WithoutCancel(ctx), job.JobId, jobs.StateFailed, err.Error())
+		return "", fmt.Errorf("erro ao enfileirar %s: %w", job.Action, err)
+	}
+	return job.JobId, nil
+}
+
+// existing procura o job que o usuário já criou com a chave de idempotência de job.
+func (s *jobScheduler) existing(ctx context.Context, job coreErrors.Job) (string, bool, error) {
+	if job.IdempotencyKey == "" {
+		return "", false, nil
+	}
+	status, err := s.store.FindByIdempotencyKey(ctx, job.UserId, job.IdempotencyKey)
+	if errors.Is(err, jobs.ErrJobNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("erro ao consultar o job: %w", err)
+	}
+	return status.Id, true, nil
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"project/internal/infrastructure/requestctx"
+)
+
+const (
+	RequestIdHeader      = "X-Request-Id"
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// RequestContextHandler coloca no contexto o X-Request-Id recebido (ou um novo) e o
+// Idempotency-Key do cliente, e devolve o X-Request-Id na resposta.
+func RequestContextHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(RequestIdHeader)
+		if requestId == "" {
+			requestId = uuid.NewString()
+		}
+		w.Header().Set(RequestIdHeader, requestId)
+
+		ctx := requestctx.WithRequestId(r.Context(), requestId)
+		ctx = requestctx.WithIdempotencyKey(ctx, r.Header.Get(IdempotencyKeyHeader))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -44,7 +44,7 @@ func (a *authMiddleware) ValidateTokenHandler(next http.Handler) http.Handler {
 			}
 		}
 
-		user, err := a.repo.GetUserByID(userId)
+		user, err := a.repo.GetUserByID(r.Context(), userId)
 		if err != nil {
 			logging.Error("validate_middleware - ValidateTokenHandler", zap.String("consult error", err.Error()))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -64,7 +64,7 @@ func (a *authMiddleware) ValidateTokenHandler(next http.Handler) http.Handler {
 				return
 			}
 
-			newAccessToken, newExpiry, err := a.auth.RefreshAccessToken(user.RefreshToken())
+			newAccessToken, newExpiry, err := a.auth.RefreshAccessToken(r.Context(), user.RefreshToken())
 			if err != nil {
 				logging.Error("ValidateToken - auth_handler", zap.String("refresh token error", err.Error()))
 				http.Error(w, "Refresh Token not found", http.StatusUnauthorized)
@@ -74,7 +74,7 @@ func (a *authMiddleware) ValidateTokenHandler(next http.Handler) http.Handler {
 			user.SetAccessToken(newAccessToken)
 			user.SetExpiresAt(newExpiry)
 
-			err = a.repo.UpdateUser(user)
+			err = a.repo.UpdateUser(r.Context(), user)
 			if err != nil {
 				logging.Error("ValidateToken - auth_handler", zap.String("update user infos error", err.Error()))
 				http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -0,0 +1,60 @@
+package quota
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Custos em unidades de cota de cada operação da YouTube Data API v3.
+// https://developers.google.com/youtube/v3/determine_quota_cost
+const (
+	ReadCost  int64 = 1
+	WriteCost int64 = 50
+)
+
+var costs = map[string]int64{
+	"playlists.list":       ReadCost,
+	"playlists.insert":     WriteCost,
+	"playlists.update":     WriteCost,
+	"playlists.delete":     WriteCost,
+	"playlistItems.list":   ReadCost,
+	"playlistItems.insert": WriteCost,
+	"playlistItems.update": WriteCost,
+	"playlistItems.delete": WriteCost,
+	"videos.list":          ReadCost,
+	"channels.list":        ReadCost,
+	"search.list":          100,
+}
+
+// Cost retorna o custo de uma operação no formato "recurso.método" (ex.: "playlists.insert").
+// Operações desconhecidas custam como leitura ou escrita conforme o método.
+func Cost(operation string) int64 {
+	if cost, ok := costs[operation]; ok {
+		return cost
+	}
+	if strings.HasSuffix(operation, ".list") {
+		return ReadCost
+	}
+	return WriteCost
+}
+
+// Operation deduz a operação da API a partir de uma requisição HTTP para /youtube/v3/<recurso>.
+func Operation(r *http.Request) string {
+	resource := strings.TrimPrefix(r.URL.Path, "/youtube/v3/")
+	if i := strings.Index(resource, "/"); i >= 0 {
+		resource = resource[:i]
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return resource + ".list"
+	case http.MethodPost:
+		return resource + ".insert"
+	case http.MethodPut:
+		return resource + ".update"
+	case http.MethodDelete:
+		return resource + ".delete"
+	default:
+		return resource + "." + strings.ToLower(r.Method)
+	}
+}
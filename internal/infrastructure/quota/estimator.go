@@ -0,0 +1,123 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Operações planejadas que podem ser estimadas.
+const (
+	PlanReorder        = "reorder"
+	PlanReorderReplace = "reorder_replace"
+	PlanMerge          = "merge"
+	PlanSplit          = "split"
+)
+
+// itemsPerPage é o máximo de itens por página de PlaylistItems.List e de ids por Videos.List.
+const itemsPerPage = 50
+
+var ErrInvalidPlan = errors.New("plano de operação inválido")
+
+// Plan descreve uma operação antes de executá-la.
+//
+// Items é o total de itens envolvidos. Playlists é o número de playlists de origem em um
+// merge ou o número de partes em um split. Rename só vale para reorder_replace.
+type Plan struct {
+	Operation string
+	Items     int
+	Playlists int
+	Rename    bool
+}
+
+type Estimate struct {
+	Operation     string `json:"operation"`
+	Items         int    `json:"items"`
+	ReadCalls     int64  `json:"read_calls"`
+	WriteCalls    int64  `json:"write_calls"`
+	Units         int64  `json:"units"`
+	Remaining     int64  `json:"remaining"`
+	ExceedsBudget bool   `json:"exceeds_budget"`
+}
+
+// EstimatePlan calcula as chamadas e as unidades de cota que a operação vai gastar,
+// seguindo os mesmos passos executados pelo serviço de playlists.
+func EstimatePlan(plan Plan) (Estimate, error) {
+	if plan.Items < 0 {
+		return Estimate{}, fmt.Errorf("%w: items não pode ser negativo", ErrInvalidPlan)
+	}
+
+	items := int64(plan.Items)
+	var reads, writes int64
+
+	switch plan.Operation {
+	case PlanReorder, PlanReorderReplace:
+		// GetPlaylistByID: Playlists.List + páginas de PlaylistItems.List + lotes de Videos.List.
+		reads = 1 + pages(items) + batches(items)
+		// CreateNewPlaylist: Playlists.Insert + um PlaylistItems.Insert por vídeo.
+		writes = 1 + items
+
+		if plan.Operation == PlanReorderReplace {
+			// verifyCopy lista a cópia; depois a original é removida.
+			reads += pages(items)
+			writes++
+			if plan.Rename {
+				writes++
+			}
+		}
+	case PlanMerge:
+		if plan.Playlists < 1 {
+			return Estimate{}, fmt.Errorf("%w: merge precisa de ao menos uma playlist", ErrInvalidPlan)
+		}
+		// Cada playlist de origem é lida separadamente; no pior caso cada uma termina com uma página parcial.
+		sources := int64(plan.Playlists)
+		reads = sources + pages(items) + (sources - 1) + batches(items) + (sources - 1)
+		writes = 1 + items
+	case PlanSplit:
+		if plan.Playlists < 1 {
+			return Estimate{}, fmt.Errorf("%w: split precisa de ao menos uma parte", ErrInvalidPlan)
+		}
+		reads = 1 + pages(items) + batches(items)
+		writes = int64(plan.Playlists) + items
+	default:
+		return Estimate{}, fmt.Errorf("%w: operação %q desconhecida", ErrInvalidPlan, plan.Operation)
+	}
+
+	return Estimate{
+		Operation:  plan.Operation,
+		Items:      plan.Items,
+		ReadCalls:  reads,
+		WriteCalls: writes,
+		Units:      reads*ReadCost + writes*WriteCost,
+	}, nil
+}
+
+// EstimateForUser estima o plano e marca se ele ultrapassa o que resta do orçamento diário do usuário.
+func EstimateForUser(ctx context.Context, ledger LedgerInterface, userId string, plan Plan) (Estimate, error) {
+	estimate, err := EstimatePlan(plan)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	usage, err := ledger.Usage(ctx, userId)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	estimate.Remaining = usage.GlobalRemaining
+	if usage.UserLimit > 0 {
+		estimate.Remaining = min(estimate.Remaining, usage.UserRemaining)
+	}
+	estimate.ExceedsBudget = estimate.Units > estimate.Remaining
+
+	return estimate, nil
+}
+
+// pages conta as chamadas de PlaylistItems.List; mesmo uma playlist vazia exige uma chamada.
+func pages(items int64) int64 {
+	return max(batches(items), 1)
+}
+
+func batches(items int64) int64 {
+	return (items + itemsPerPage - 1) / itemsPerPage
+}
@@ -0,0 +1,89 @@
+package quota_test
+
+import (
+	"errors"
+	"testing"
+
+	"project/internal/infrastructure/quota"
+)
+
+func TestEstimateReorder(t *testing.T) {
+	estimate, err := quota.EstimatePlan(quota.Plan{Operation: quota.PlanReorder, Items: 120})
+	if err != nil {
+		t.Fatalf("Erro ao estimar reorder: %v", err)
+	}
+
+	// 1 Playlists.List + 3 páginas de PlaylistItems.List + 3 lotes de Videos.List.
+	if estimate.ReadCalls != 7 {
+		t.Errorf("Esperado 7 leituras, obtido %d", estimate.ReadCalls)
+	}
+	// 1 Playlists.Insert + 120 PlaylistItems.Insert.
+	if estimate.WriteCalls != 121 {
+		t.Errorf("Esperado 121 escritas, obtido %d", estimate.WriteCalls)
+	}
+	if estimate.Units != 7+121*50 {
+		t.Errorf("Esperado %d unidades, obtido %d", 7+121*50, estimate.Units)
+	}
+}
+
+func TestEstimateReorderReplaceWithRename(t *testing.T) {
+	reorder, _ := quota.EstimatePlan(quota.Plan{Operation: quota.PlanReorder, Items: 50})
+	replace, err := quota.EstimatePlan(quota.Plan{Operation: quota.PlanReorderReplace, Items: 50, Rename: true})
+	if err != nil {
+		t.Fatalf("Erro ao estimar reorder_replace: %v", err)
+	}
+
+	if replace.ReadCalls != reorder.ReadCalls+1 {
+		t.Errorf("Esperado %d leituras, obtido %d", reorder.ReadCalls+1, replace.ReadCalls)
+	}
+	if replace.WriteCalls != reorder.WriteCalls+2 {
+		t.Errorf("Esperado %d escritas, obtido %d", reorder.WriteCalls+2, replace.WriteCalls)
+	}
+}
+
+func TestEstimateEmptyPlaylistStillListsOnce(t *testing.T) {
+	estimate, err := quota.EstimatePlan(quota.Plan{Operation: quota.PlanReorder, Items: 0})
+	if err != nil {
+		t.Fatalf("Erro ao estimar playlist vazia: %v", err)
+	}
+
+	if estimate.ReadCalls != 2 || estimate.WriteCalls != 1 {
+		t.Errorf("Esperado 2 leituras e 1 escrita, obtido %d e %d", estimate.ReadCalls, estimate.WriteCalls)
+	}
+}
+
+func TestEstimateSplitAndMerge(t *testing.T) {
+	split, err := quota.EstimatePlan(quota.Plan{Operation: quota.PlanSplit, Items: 100, Playlists: 4})
+	if err != nil {
+		t.Fatalf("Erro ao estimar split: %v", err)
+	}
+	if split.WriteCalls != 104 {
+		t.Errorf("Esperado 104 escritas no split, obtido %d", split.WriteCalls)
+	}
+
+	merge, err := quota.EstimatePlan(quota.Plan{Operation: quota.PlanMerge, Items: 100, Playlists: 3})
+	if err != nil {
+		t.Fatalf("Erro ao estimar merge: %v", err)
+	}
+	// 3 Playlists.List + (2 + 2) páginas + (2 + 2) lotes de vídeos, no pior caso.
+	if merge.ReadCalls != 11 {
+		t.Errorf("Esperado 11 leituras no merge, obtido %d", merge.ReadCalls)
+	}
+	if merge.WriteCalls != 101 {
+		t.Errorf("Esperado 101 escritas no merge, obtido %d", merge.WriteCalls)
+	}
+}
+
+func TestEstimateInvalidPlan(t *testing.T) {
+	cases := []quota.Plan{
+		{Operation: "shuffle", Items: 10},
+		{Operation: quota.PlanReorder, Items: -1},
+		{Operation: quota.PlanSplit, Items: 10},
+	}
+
+	for _, plan := range cases {
+		if _, err := quota.EstimatePlan(plan); !errors.Is(err, quota.ErrInvalidPlan) {
+			t.Errorf("Esperado ErrInvalidPlan para %+v, obtido %v", plan, err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	_ "time/tzdata"
+
+	"go.uber.org/zap"
+	"project/internal/infrastructure/cache"
+	"project/internal/infrastructure/logging"
+)
+
+// DefaultDailyLimit é a cota diária padrão de um projeto da YouTube Data API.
+const DefaultDailyLimit int64 = 10000
+
+const (
+	totalField = "total"
+	// Os contadores de um dia ficam disponíveis por mais um dia para consulta.
+	keyTTL = 48 * time.Hour
+)
+
+var ErrBudgetExceeded = errors.New("orçamento diário de cota do YouTube esgotado")
+
+// A cota do YouTube é zerada à meia-noite no horário do Pacífico.
+var pacific = mustLoadLocation("America/Los_Angeles")
+
+// Budget define os limites diários em unidades de cota. PerUser zero significa sem limite por usuário.
+type Budget struct {
+	Global  int64
+	PerUser int64
+}
+
+type Usage struct {
+	Day             string           `json:"day"`
+	ResetAt         time.Time        `json:"reset_at"`
+	UserUnits       int64            `json:"user_units"`
+	UserLimit       int64            `json:"user_limit,omitempty"`
+	UserRemaining   int64            `json:"user_remaining,omitempty"`
+	GlobalUnits     int64            `json:"global_units"`
+	GlobalLimit     int64            `json:"global_limit"`
+	GlobalRemaining int64            `json:"global_remaining"`
+	ByOperation     map[string]int64 `json:"by_operation"`
+}
+
+// LedgerInterface contabiliza as unidades de cota gastas por operação, usuário e dia.
+type LedgerInterface interface {
+	Record(ctx context.Context, userId, operation string) error
+	Usage(ctx context.Context, userId string) (Usage, error)
+	CheckBudget(ctx context.Context, userId string, units int64) error
+}
+
+type ledger struct {
+	cache  cache.RedisCacheInterface
+	budget Budget
+	now    func() time.Time
+}
+
+func NewLedger(cache cache.RedisCacheInterface, budget Budget) LedgerInterface {
+	if budget.Global <= 0 {
+		budget.Global = DefaultDailyLimit
+	}
+	return &ledger{cache: cache, budget: budget, now: time.Now}
+}
+
+func (l *ledger) Record(ctx context.Context, userId, operation string) error {
+	units := Cost(operation)
+	day := Day(l.now())
+
+	for _, key := range []string{userKey(day, userId), globalKey(day)} {
+		if _, err := l.cache.HIncrBy(ctx, key, operation, units); err != nil {
+			return err
+		}
+		if _, err := l.cache.HIncrBy(ctx, key, totalField, units); err != nil {
+			return err
+		}
+		if err := l.cache.Expire(ctx, key, keyTTL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *ledger) Usage(ctx context.Context, userId string) (Usage, error) {
+	now := l.now()
+	day := Day(now)
+
+	userCounters, err := l.counters(ctx, userKey(day, userId))
+	if err != nil {
+		return Usage{}, err
+	}
+	globalCounters, err := l.counters(ctx, globalKey(day))
+	if err != nil {
+		return Usage{}, err
+	}
+
+	usage := Usage{
+		Day:             day,
+		ResetAt:         ResetAt(now),
+		UserUnits:       userCounters[totalField],
+		GlobalUnits:     globalCounters[totalField],
+		GlobalLimit:     l.budget.Global,
+		GlobalRemaining: max(l.budget.Global-globalCounters[totalField], 0),
+		ByOperation:     make(map[string]int64, len(userCounters)),
+	}
+	if l.budget.PerUser > 0 {
+		usage.UserLimit = l.budget.PerUser
+		usage.UserRemaining = max(l.budget.PerUser-usage.UserUnits, 0)
+	}
+
+	for operation, units := range userCounters {
+		if operation != totalField {
+			usage.ByOperation[operation] = units
+		}
+	}
+
+	return usage, nil
+}
+
+// CheckBudget falha com ErrBudgetExceeded quando gastar units ultrapassaria o orçamento do usuário ou o global.
+func (l *ledger) CheckBudget(ctx context.Context, userId string, units int64) error {
+	usage, err := l.Usage(ctx, userId)
+	if err != nil {
+		// Sem como medir o consumo, não bloqueia a operação; o próprio YouTube ainda aplica a cota real.
+		logging.Error("Erro ao consultar o consumo de cota", zap.String("user_id", userId), zap.Error(err))
+		return nil
+	}
+
+	if usage.GlobalUnits+units > usage.GlobalLimit {
+		return fmt.Errorf("%w: global (%d de %d unidades usadas, %d necessárias)", ErrBudgetExceeded, usage.GlobalUnits, usage.GlobalLimit, units)
+	}
+	if usage.UserLimit > 0 && usage.UserUnits+units > usage.UserLimit {
+		return fmt.Errorf("%w: usuário (%d de %d unidades usadas, %d necessárias)", ErrBudgetExceeded, usage.UserUnits, usage.UserLimit, units)
+	}
+
+	return nil
+}
+
+func (l *ledger) counters(ctx context.Context, key string) (map[string]int64, error) {
+	values, err := l.cache.HGetAll(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make(map[string]int64, len(values))
+	for field, value := range values {
+		units, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		counters[field] = units
+	}
+	return counters, nil
+}
+
+// Day retorna o dia de cota (horário do Pacífico) ao qual t pertence.
+func Day(t time.Time) string {
+	return t.In(pacific).Format(time.DateOnly)
+}
+
+// ResetAt retorna o instante em que a cota do dia de t é zerada.
+func ResetAt(t time.Time) time.Time {
+	local := t.In(pacific)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, pacific)
+}
+
+func userKey(day, userId string) string {
+	return "quota:" + day + ":user:" + userId
+}
+
+func globalKey(day string) string {
+	return "quota:" + day + ":global"
+}
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
@@ -0,0 +1,40 @@
+package quota
+
+import (
+	"context"
+	"net/http"
+
+	"go.uber.org/zap"
+	"project/internal/infrastructure/logging"
+)
+
+// transport registra no ledger cada requisição respondida pela API, inclusive as respostas
+// de erro, já que o YouTube também cobra cota por elas.
+type transport struct {
+	base   http.RoundTripper
+	ledger LedgerInterface
+	userId string
+}
+
+func NewTransport(base http.RoundTripper, ledger LedgerInterface, userId string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, ledger: ledger, userId: userId}
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	response, err := t.base.RoundTrip(r)
+	if err != nil {
+		// A requisição nem chegou a ser respondida pela API, então não consumiu cota.
+		return response, err
+	}
+
+	// A cota já foi gasta: o registro não pode ser perdido se o chamador cancelar logo em seguida.
+	operation := Operation(r)
+	if recordErr := t.ledger.Record(context.WithoutCancel(r.Context()), t.userId, operation); recordErr != nil {
+		logging.Error("Erro ao registrar consumo de cota", zap.String("user_id", t.userId), zap.String("operation", operation), zap.Error(recordErr))
+	}
+
+	return response, nil
+}
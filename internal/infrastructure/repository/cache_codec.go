@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"project/internal/DTOs"
+	"project/internal/core/entities"
+)
+
+// Formatos aceitos em CACHE_CODEC.
+const (
+	CodecJSON = "json"
+	CodecGzip = "gzip"
+)
+
+// gzipMinSize é o tamanho mínimo de um registro para ser comprimido; abaixo disso o
+// cabeçalho do gzip ocupa mais do que a compressão economiza.
+const gzipMinSize = 512
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// CacheCodec converte as entidades nos registros gravados no Redis, sempre passando pelos
+// DTOs versionados. A leitura reconhece os dois formatos, então trocar o codec não invalida
+// o que já está em cache.
+type CacheCodec interface {
+	Name() string
+	EncodeVideo(video entities.VideoInterface) ([]byte, error)
+	DecodeVideo(data []byte) (entities.VideoInterface, error)
+	EncodePlaylist(playlist entities.PlaylistInterface) ([]byte, error)
+	DecodePlaylist(data []byte) (entities.PlaylistInterface, error)
+	EncodePlaylistVideos(videoIds []string) ([]byte, error)
+	// DecodePlaylistVideos devolve também os vídeos completos guardados pelos registros antigos.
+	DecodePlaylistVideos(data []byte) ([]string, []entities.VideoInterface, error)
+	// Current indica se data já está na versão atual do schema e no formato que o codec gravaria hoje.
+	Current(data []byte) bool
+}
+
+type cacheCodec struct {
+	compress bool
+}
+
+// NewCacheCodec cria o codec pelo nome; vazio equivale a CodecJSON.
+func NewCacheCodec(name string) (CacheCodec, error) {
+	switch name {
+	case "", CodecJSON:
+		return &cacheCodec{}, nil
+	case CodecGzip:
+		return &cacheCodec{compress: true}, nil
+	default:
+		return nil, fmt.Errorf("codec de cache desconhecido: %q", name)
+	}
+}
+
+// codecOrDefault permite construir os repositórios sem codec, gravando JSON.
+func codecOrDefault(codec CacheCodec) CacheCodec {
+	if codec == nil {
+		return &cacheCodec{}
+	}
+	return codec
+}
+
+func (c *cacheCodec) Name() string {
+	if c.compress {
+		return CodecGzip
+	}
+	return CodecJSON
+}
+
+func (c *cacheCodec) EncodeVideo(video entities.VideoInterface) ([]byte, error) {
+	return c.pack(encodeVideo(video))
+}
+
+func (c *cacheCodec) DecodeVideo(data []byte) (entities.VideoInterface, error) {
+	plain, err := unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	video, _, err := decodeVideo(plain)
+	return video, err
+}
+
+func (c *cacheCodec) EncodePlaylist(playlist entities.PlaylistInterface) ([]byte, error) {
+	return c.pack(encodePlaylistSummary(playlist))
+}
+
+func (c *cacheCodec) DecodePlaylist(data []byte) (entities.PlaylistInterface, error) {
+	plain, err := unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	playlist, _, err := decodePlaylistSummary(plain)
+	return playlist, err
+}
+
+func (c *cacheCodec) EncodePlaylistVideos(videoIds []string) ([]byte, error) {
+	return c.pack(encodePlaylistVideos(videoIds))
+}
+
+func (c *cacheCodec) DecodePlaylistVideos(data []byte) ([]string, []entities.VideoInterface, error) {
+	plain, err := unpack(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids, legacy, _, err := decodePlaylistVideos(plain)
+	return ids, legacy, err
+}
+
+func (c *cacheCodec) Current(data []byte) bool {
+	plain, err := unpack(data)
+	if err != nil {
+		return false
+	}
+	version, err := recordVersion(plain)
+	if err != nil || version != DTOs.CacheSchemaVersion {
+		return false
+	}
+	return isGzip(data) == c.shouldCompress(plain)
+}
+
+func (c *cacheCodec) shouldCompress(plain []byte) bool {
+	return c.compress && len(plain) >= gzipMinSize
+}
+
+func (c *cacheCodec) pack(plain []byte, err error) ([]byte, error) {
+	if err != nil || !c.shouldCompress(plain) {
+		return plain, err
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(plain); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unpack devolve o JSON do registro, descomprimindo quando ele foi gravado com gzip.
+func unpack(data []byte) ([]byte, error) {
+	if !isGzip(data) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIncompatibleSchema, err)
+	}
+	defer reader.Close()
+
+	plain, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIncompatibleSchema, err)
+	}
+	return plain, nil
+}
+
+func isGzip(data []byte) bool {
+	return bytes.HasPrefix(data, gzipMagic)
+}
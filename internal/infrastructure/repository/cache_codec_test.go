@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"project/internal/core/entities"
+	"project/internal/infrastructure/repository"
+)
+
+func TestGzipCodecCompressesLargeRecordsOnly(t *testing.T) {
+	ctx := context.Background()
+	fc := newFakeCache()
+	gzipCodec, err := repository.NewCacheCodec(repository.CodecGzip)
+	if err != nil {
+		t.Fatalf("Erro ao criar o codec: %v", err)
+	}
+	repo := repository.NewPlaylistRepositoryRedis(fc, repository.NewVideoRepositoryRedis(fc, gzipCodec, 0), gzipCodec, 0)
+
+	videos := make([]entities.VideoInterface, 100)
+	for i := range videos {
+		videos[i] = entities.NewVideo(fmt.Sprintf("video%03d", i), "Video", "channel1", "pt", time.Now(), time.Minute)
+	}
+	if err := repo.SavePlaylistVideos(ctx, "user123", "playlist1", videos); err != nil {
+		t.Fatalf("Erro ao salvar vídeos: %v", err)
+	}
+
+	if !bytes.HasPrefix([]byte(fc.data["playlist_videos:user123:playlist1"]), []byte{0x1f, 0x8b}) {
+		t.Error("A lista de ids da playlist grande deveria ser gravada com gzip")
+	}
+	if !strings.HasPrefix(fc.data["video:video000"], "{") {
+		t.Errorf("Registros pequenos deveriam continuar em JSON, obtido %q", fc.data["video:video000"])
+	}
+
+	// Um repositório configurado com JSON continua lendo o que foi gravado com gzip.
+	jsonRepo := repository.NewPlaylistRepositoryRedis(fc, repository.NewVideoRepositoryRedis(fc, nil, 0), nil, 0)
+	cached, err := jsonRepo.GetPlaylistVideos(ctx, "user123", "playlist1")
+	if err != nil || len(cached) != 100 || cached[99].Id() != "video099" {
+		t.Fatalf("Esperado ler os 100 vídeos com o codec JSON, obtido %d (%v)", len(cached), err)
+	}
+
+	report, err := repository.NewCacheMigrator(fc, nil).Migrate(ctx, false)
+	if err != nil || report.Upgraded != 1 {
+		t.Fatalf("O migrador deveria regravar só o registro comprimido, obtido %+v (%v)", report, err)
+	}
+	if !strings.HasPrefix(fc.data["playlist_videos:user123:playlist1"], "{") {
+		t.Error("Depois da migração para JSON o registro não deveria estar comprimido")
+	}
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"project/internal/infrastructure/cache"
+	"project/internal/infrastructure/logging"
+)
+
+// legacyPlaylistIndexKey é o hash que apontava cada playlist para o último usuário que a gravou.
+const legacyPlaylistIndexKey = "playlistIndex"
+
+var errUnscopedVideosKey = errors.New("vídeos em cache sem o usuário na chave")
+
+// MigrationReport resume uma passada do migrador pelo cache.
+type MigrationReport struct {
+	Scanned  int
+	Upgraded int
+	Purged   int
+}
+
+// CacheMigratorInterface atualiza os registros do cache para DTOs.CacheSchemaVersion e para o
+// formato do codec configurado.
+type CacheMigratorInterface interface {
+	// Migrate reescreve os registros de versões antigas, mantendo o TTL, e remove os que não
+	// podem ser lidos. Com dryRun apenas conta o que seria feito.
+	Migrate(ctx context.Context, dryRun bool) (MigrationReport, error)
+}
+
+type cacheMigrator struct {
+	client cache.RedisCacheInterface
+	codec  CacheCodec
+	videos VideoRepositoryRedisInterface
+	dryRun bool
+	report MigrationReport
+}
+
+func NewCacheMigrator(client cache.RedisCacheInterface, codec CacheCodec) CacheMigratorInterface {
+	codec = codecOrDefault(codec)
+	return &cacheMigrator{client: client, codec: codec, videos: NewVideoRepositoryRedis(client, codec, 0)}
+}
+
+func (m *cacheMigrator) Migrate(ctx context.Context, dryRun bool) (MigrationReport, error) {
+	m.dryRun = dryRun
+	m.report = MigrationReport{}
+
+	steps := []struct {
+		pattern string
+		migrate func(ctx context.Context, key string) error
+	}{
+		{videoKey("*"), m.migrateVideo},
+		// O padrão também pega as chaves antigas, sem o usuário, para que sejam removidas.
+		{videosKeyPrefix + "*", m.migratePlaylistVideos},
+		{playlistsKey("*"), m.migratePlaylists},
+	}
+	for _, step := range steps {
+		keys, err := m.client.Keys(ctx, step.pattern)
+		if err != nil {
+			return m.report, err
+		}
+		for _, key := range keys {
+			if strings.HasSuffix(key, ":fetched_at") {
+				continue
+			}
+			if err := step.migrate(ctx, key); err != nil {
+				return m.report, err
+			}
+		}
+	}
+	return m.report, m.dropPlaylistIndex(ctx)
+}
+
+// dropPlaylistIndex remove o índice global playlist -> usuário, que não é mais usado: a
+// playlist é removida sempre da listagem de quem pediu.
+func (m *cacheMigrator) dropPlaylistIndex(ctx context.Context) error {
+	fields, err := m.client.HGetAll(ctx, legacyPlaylistIndexKey)
+	if err != nil || len(fields) == 0 {
+		return err
+	}
+	return m.purge(ctx, legacyPlaylistIndexKey, errors.New("índice global de playlists obsoleto"))
+}
+
+func (m *cacheMigrator) migrateVideo(ctx context.Context, key string) error {
+	data, ok, err := m.get(ctx, key)
+	if err != nil || !ok {
+		return err
+	}
+
+	video, err := m.codec.DecodeVideo(data)
+	if err != nil {
+		return m.purge(ctx, key, err)
+	}
+	if m.codec.Current(data) {
+		return nil
+	}
+
+	encoded, err := m.codec.EncodeVideo(video)
+	if err != nil {
+		return err
+	}
+	return m.rewrite(ctx, key, encoded)
+}
+
+func (m *cacheMigrator) migratePlaylistVideos(ctx context.Context, key string) error {
+	data, ok, err := m.get(ctx, key)
+	if err != nil || !ok {
+		return err
+	}
+
+	ids, legacy, err := m.codec.DecodePlaylistVideos(data)
+	// Chaves sem o usuário eram compartilhadas entre contas e não são mais lidas.
+	if err == nil && !strings.Contains(strings.TrimPrefix(key, videosKeyPrefix), ":") {
+		err = errUnscopedVideosKey
+	}
+	if err != nil {
+		if err := m.purge(ctx, key, err); err != nil {
+			return err
+		}
+		// Sem a lista, a marcação de busca faria a playlist parecer em cache.
+		if m.dryRun {
+			return nil
+		}
+		return m.client.Delete(ctx, fetchedAtKey(key))
+	}
+	if m.codec.Current(data) {
+		return nil
+	}
+
+	// Os vídeos completos dos registros antigos passam para o cache compartilhado.
+	if len(legacy) > 0 && !m.dryRun {
+		if err := m.videos.SaveVideos(ctx, legacy); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := m.codec.EncodePlaylistVideos(ids)
+	if err != nil {
+		return err
+	}
+	return m.rewrite(ctx, key, encoded)
+}
+
+// migratePlaylists atualiza cada resumo do hash. Um resumo ilegível invalida a listagem
+// inteira, para que a próxima leitura busque tudo de novo no YouTube.
+func (m *cacheMigrator) migratePlaylists(ctx context.Context, key string) error {
+	fields, err := m.client.HGetAll(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	upgraded := make(map[string][]byte)
+	for field, value := range fields {
+		m.report.Scanned++
+
+		playlist, err := m.codec.DecodePlaylist([]byte(value))
+		if err != nil {
+			logging.Info("Listagem com resumo incompatível no cache", zap.String("key", key), zap.String("playlist_id", field), zap.Error(err))
+			m.report.Purged++
+			if m.dryRun {
+				return nil
+			}
+			if err := m.client.Delete(ctx, key); err != nil {
+				return err
+			}
+			return m.client.Delete(ctx, fetchedAtKey(key))
+		}
+		if m.codec.Current([]byte(value)) {
+			continue
+		}
+
+		encoded, err := m.codec.EncodePlaylist(playlist)
+		if err != nil {
+			return err
+		}
+		upgraded[field] = encoded
+	}
+
+	for field, encoded := range upgraded {
+		m.report.Upgraded++
+		if m.dryRun {
+			continue
+		}
+		if err := m.client.HSet(ctx, key, field, string(encoded)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// get lê uma chave de string; ok é falso quando ela expirou entre a listagem e a leitura.
+func (m *cacheMigrator) get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := m.client.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	m.report.Scanned++
+	return []byte(value.(string)), true, nil
+}
+
+func (m *cacheMigrator) rewrite(ctx context.Context, key string, data []byte) error {
+	m.report.Upgraded++
+	if m.dryRun {
+		return nil
+	}
+	return m.client.Set(ctx, key, string(data), redis.KeepTTL)
+}
+
+func (m *cacheMigrator) purge(ctx context.Context, key string, reason error) error {
+	logging.Info("Registro incompatível no cache", zap.String("key", key), zap.Error(reason))
+	m.report.Purged++
+	if m.dryRun {
+		return nil
+	}
+	return m.client.Delete(ctx, key)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"project/internal/DTOs"
+	"project/internal/core/entities"
+)
+
+// ErrIncompatibleSchema indica um registro do cache que não pode ser lido nem atualizado:
+// JSON inválido, campos obrigatórios vazios ou versão mais nova que DTOs.CacheSchemaVersion.
+var ErrIncompatibleSchema = errors.New("registro do cache em formato incompatível")
+
+// recordVersion lê o campo "v" de um registro; registros anteriores ao versionamento são da versão 1.
+func recordVersion(data []byte) (int, error) {
+	var probe struct {
+		Version int `json:"v"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrIncompatibleSchema, err)
+	}
+	if probe.Version == 0 {
+		return 1, nil
+	}
+	if probe.Version > DTOs.CacheSchemaVersion {
+		return 0, fmt.Errorf("%w: versão %d", ErrIncompatibleSchema, probe.Version)
+	}
+	return probe.Version, nil
+}
+
+func encodeVideo(video entities.VideoInterface) ([]byte, error) {
+	return json.Marshal(DTOs.VideoCacheFromEntity(video))
+}
+
+// decodeVideo lê um registro de video:<id> de qualquer versão suportada e devolve também a versão lida.
+func decodeVideo(data []byte) (entities.VideoInterface, int, error) {
+	version, err := recordVersion(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var video entities.VideoInterface
+	switch version {
+	case 1:
+		var dto DTOs.VideoRedisDTO
+		if err := json.Unmarshal(data, &dto); err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", ErrIncompatibleSchema, err)
+		}
+		video = dto.ToEntity()
+	default:
+		var dto DTOs.VideoCacheDTO
+		if err := json.Unmarshal(data, &dto); err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", ErrIncompatibleSchema, err)
+		}
+		video = dto.ToEntity()
+	}
+
+	if strings.TrimSpace(video.Id()) == "" {
+		return nil, 0, fmt.Errorf("%w: vídeo sem id", ErrIncompatibleSchema)
+	}
+	return video, version, nil
+}
+
+func encodePlaylistSummary(playlist entities.PlaylistInterface) ([]byte, error) {
+	return json.Marshal(DTOs.PlaylistCacheFromEntity(playlist))
+}
+
+// decodePlaylistSummary lê um resumo do hash playlists:<userId>. A versão 1 tem os mesmos
+// campos da atual, então só muda a versão devolvida.
+func decodePlaylistSummary(data []byte) (entities.PlaylistInterface, int, error) {
+	version, err := recordVersion(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var dto DTOs.PlaylistCacheDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrIncompatibleSchema, err)
+	}
+	if strings.TrimSpace(dto.Id) == "" || strings.TrimSpace(dto.Title) == "" {
+		return nil, 0, fmt.Errorf("%w: playlist sem id ou título", ErrIncompatibleSchema)
+	}
+	dto.Videos = nil
+	return dto.ToEntity(), version, nil
+}
+
+func encodePlaylistVideos(videoIds []string) ([]byte, error) {
+	return json.Marshal(DTOs.PlaylistVideosCacheDTO{Version: DTOs.CacheSchemaVersion, VideoIds: videoIds})
+}
+
+// decodePlaylistVideos lê um registro de playlist_videos:<userId>:<playlistId>. Registros da versão 1
+// com os vídeos completos devolvem também esses vídeos, que ainda não estão em video:<id>.
+func decodePlaylistVideos(data []byte) ([]string, []entities.VideoInterface, int, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var ids []string
+		if err := json.Unmarshal(trimmed, &ids); err == nil {
+			return ids, nil, 1, nil
+		}
+
+		var dtos []DTOs.VideoRedisDTO
+		if err := json.Unmarshal(trimmed, &dtos); err != nil {
+			return nil, nil, 0, fmt.Errorf("%w: %v", ErrIncompatibleSchema, err)
+		}
+		ids = make([]string, len(dtos))
+		videos := make([]entities.VideoInterface, len(dtos))
+		for i, dto := range dtos {
+			ids[i] = dto.Id
+			videos[i] = dto.ToEntity()
+		}
+		return ids, videos, 1, nil
+	}
+
+	version, err := recordVersion(data)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if version < DTOs.CacheSchemaVersion {
+		return nil, nil, 0, fmt.Errorf("%w: objeto sem versão", ErrIncompatibleSchema)
+	}
+
+	var dto DTOs.PlaylistVideosCacheDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, nil, 0, fmt.Errorf("%w: %v", ErrIncompatibleSchema, err)
+	}
+	return dto.VideoIds, nil, version, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UserCacheInfo descreve o que está em cache para um usuário.
+type UserCacheInfo struct {
+	UserId string `json:"user_id"`
+	// FetchedAt é quando a listagem foi buscada no YouTube; zero se ela não está em cache.
+	FetchedAt  time.Time            `json:"fetched_at"`
+	AgeSeconds float64              `json:"age_seconds"`
+	TotalBytes int                  `json:"total_bytes"`
+	Playlists  []CachedPlaylistInfo `json:"playlists"`
+}
+
+// CachedPlaylistInfo descreve o resumo e os vídeos em cache de uma playlist. Os tamanhos são
+// os dos registros gravados, sem contar os metadados compartilhados em video:<id>.
+type CachedPlaylistInfo struct {
+	PlaylistId      string    `json:"playlist_id"`
+	Title           string    `json:"title,omitempty"`
+	SummaryBytes    int       `json:"summary_bytes"`
+	VideosCached    bool      `json:"videos_cached"`
+	VideosBytes     int       `json:"videos_bytes"`
+	VideosFetchedAt time.Time `json:"videos_fetched_at"`
+	VideosAgeSecs   float64   `json:"videos_age_seconds"`
+}
+
+func (pr *playlistRepositoryRedis) DescribeUserCache(ctx context.Context, userId string) (UserCacheInfo, error) {
+	fetchedAt, err := pr.PlaylistsFetchedAt(ctx, userId)
+	if err != nil {
+		return UserCacheInfo{}, err
+	}
+	fields, err := pr.client.HGetAll(ctx, playlistsKey(userId))
+	if err != nil {
+		return UserCacheInfo{}, err
+	}
+
+	now := time.Now()
+	info := UserCacheInfo{UserId: userId, FetchedAt: fetchedAt, AgeSeconds: ageSeconds(now, fetchedAt)}
+	for playlistId, value := range fields {
+		playlist := CachedPlaylistInfo{PlaylistId: playlistId, SummaryBytes: len(value)}
+		if decoded, err := pr.codec.DecodePlaylist([]byte(value)); err == nil {
+			playlist.Title = decoded.Title()
+		}
+
+		videos, err := pr.client.Get(ctx, videosKey(userId, playlistId))
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return UserCacheInfo{}, err
+		}
+		if err == nil {
+			playlist.VideosCached = true
+			playlist.VideosBytes = len(videos.(string))
+			if playlist.VideosFetchedAt, err = pr.VideosFetchedAt(ctx, userId, playlistId); err != nil {
+				return UserCacheInfo{}, err
+			}
+			playlist.VideosAgeSecs = ageSeconds(now, playlist.VideosFetchedAt)
+		}
+
+		info.TotalBytes += playlist.SummaryBytes + playlist.VideosBytes
+		info.Playlists = append(info.Playlists, playlist)
+	}
+
+	sort.Slice(info.Playlists, func(i, j int) bool {
+		return info.Playlists[i].PlaylistId < info.Playlists[j].PlaylistId
+	})
+	return info, nil
+}
+
+// EvictUser remove a listagem do usuário e os vídeos de cada playlist dela. Os metadados em
+// video:<id> são compartilhados com outros usuários e ficam até expirar.
+func (pr *playlistRepositoryRedis) EvictUser(ctx context.Context, userId string) error {
+	fields, err := pr.client.HGetAll(ctx, playlistsKey(userId))
+	if err != nil {
+		return err
+	}
+
+	for playlistId := range fields {
+		if err := pr.InvalidatePlaylistVideos(ctx, userId, playlistId); err != nil {
+			return err
+		}
+	}
+	return pr.InvalidatePlaylists(ctx, userId)
+}
+
+func ageSeconds(now, fetchedAt time.Time) float64 {
+	if fetchedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(fetchedAt).Seconds()
+}
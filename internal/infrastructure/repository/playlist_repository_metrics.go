@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"project/internal/core/entities"
+	"project/internal/infrastructure/cache"
+)
+
+// instrumentedPlaylistRepository registra cada operação do repositório em metrics, com o nome
+// "playlist_repository.<operação>". Nas leituras, um cache vazio ou ausente conta como miss.
+type instrumentedPlaylistRepository struct {
+	next    PlaylistRepositoryRedisInterface
+	metrics cache.MetricsInterface
+}
+
+// WithMetrics devolve next com hits, misses, erros e latência registrados em metrics.
+func WithMetrics(next PlaylistRepositoryRedisInterface, metrics cache.MetricsInterface) PlaylistRepositoryRedisInterface {
+	return &instrumentedPlaylistRepository{next: next, metrics: metrics}
+}
+
+// errEmpty marca leituras que não falharam mas não encontraram nada.
+var errEmpty = errors.New("vazio")
+
+func (r *instrumentedPlaylistRepository) observe(operation string, start time.Time, outcome string) {
+	r.metrics.Observe("playlist_repository."+operation, outcome, time.Since(start))
+}
+
+func (r *instrumentedPlaylistRepository) GetPlaylistById(ctx context.Context, playlistId string, userId string) (entities.PlaylistInterface, error) {
+	start := time.Now()
+	playlist, err := r.next.GetPlaylistById(ctx, playlistId, userId)
+	r.observe("get_playlist_by_id", start, cache.ReadOutcome(err, ErrPlaylistNotCached))
+	return playlist, err
+}
+
+func (r *instrumentedPlaylistRepository) SavePlaylist(ctx context.Context, userId string, playlist entities.PlaylistInterface) error {
+	start := time.Now()
+	err := r.next.SavePlaylist(ctx, userId, playlist)
+	r.observe("save_playlist", start, cache.WriteOutcome(err))
+	return err
+}
+
+func (r *instrumentedPlaylistRepository) SaveAllPlaylists(ctx context.Context, userId string, playlists []entities.PlaylistInterface) error {
+	start := time.Now()
+	err := r.next.SaveAllPlaylists(ctx, userId, playlists)
+	r.observe("save_all_playlists", start, cache.WriteOutcome(err))
+	return err
+}
+
+func (r *instrumentedPlaylistRepository) DeletePlaylist(ctx context.Context, userId, playlistId string) error {
+	start := time.Now()
+	err := r.next.DeletePlaylist(ctx, userId, playlistId)
+	r.observe("delete_playlist", start, cache.WriteOutcome(err))
+	return err
+}
+
+func (r *instrumentedPlaylistRepository) GetAllPlaylistsByUserID(ctx context.Context, userId string) ([]entities.PlaylistInterface, error) {
+	start := time.Now()
+	playlists, err := r.next.GetAllPlaylistsByUserID(ctx, userId)
+	outcome := cache.ReadOutcome(err, errEmpty)
+	if err == nil && len(playlists) == 0 {
+		outcome = cache.OutcomeMiss
+	}
+	r.observe("get_all_playlists", start, outcome)
+	return playlists, err
+}
+
+func (r *instrumentedPlaylistRepository) GetPlaylistVideos(ctx context.Context, userId, playlistId string) ([]entities.VideoInterface, error) {
+	start := time.Now()
+	videos, err := r.next.GetPlaylistVideos(ctx, userId, playlistId)
+	r.observe("get_playlist_videos", start, cache.ReadOutcome(err, ErrVideosNotCached))
+	return videos, err
+}
+
+func (r *instrumentedPlaylistRepository) SavePlaylistVideos(ctx context.Context, userId, playlistId string, videos []entities.VideoInterface) error {
+	start := time.Now()
+	err := r.next.SavePlaylistVideos(ctx, userId, playlistId, videos)
+	r.observe("save_playlist_videos", start, cache.WriteOutcome(err))
+	return err
+}
+
+func (r *instrumentedPlaylistRepository) PlaylistsFetchedAt(ctx context.Context, userId string) (time.Time, error) {
+	start := time.Now()
+	fetchedAt, err := r.next.PlaylistsFetchedAt(ctx, userId)
+	r.observe("playlists_fetched_at", start, fetchedAtOutcome(fetchedAt, err))
+	return fetchedAt, err
+}
+
+func (r *instrumentedPlaylistRepository) VideosFetchedAt(ctx context.Context, userId, playlistId string) (time.Time, error) {
+	start := time.Now()
+	fetchedAt, err := r.next.VideosFetchedAt(ctx, userId, playlistId)
+	r.observe("videos_fetched_at", start, fetchedAtOutcome(fetchedAt, err))
+	return fetchedAt, err
+}
+
+func (r *instrumentedPlaylistRepository) InvalidatePlaylists(ctx context.Context, userId string) error {
+	start := time.Now()
+	err := r.next.InvalidatePlaylists(ctx, userId)
+	r.observe("invalidate_playlists", start, cache.WriteOutcome(err))
+	return err
+}
+
+func (r *instrumentedPlaylistRepository) InvalidatePlaylistVideos(ctx context.Context, userId, playlistId string) error {
+	start := time.Now()
+	err := r.next.InvalidatePlaylistVideos(ctx, userId, playlistId)
+	r.observe("invalidate_playlist_videos", start, cache.WriteOutcome(err))
+	return err
+}
+
+func (r *instrumentedPlaylistRepository) DescribeUserCache(ctx context.Context, userId string) (UserCacheInfo, error) {
+	return r.next.DescribeUserCache(ctx, userId)
+}
+
+func (r *instrumentedPlaylistRepository) EvictUser(ctx context.Context, userId string) error {
+	start := time.Now()
+	err := r.next.EvictUser(ctx, userId)
+	r.observe("evict_user", start, cache.WriteOutcome(err))
+	return err
+}
+
+func fetchedAtOutcome(fetchedAt time.Time, err error) string {
+	if err == nil && fetchedAt.IsZero() {
+		return cache.OutcomeMiss
+	}
+	return cache.ReadOutcome(err, errEmpty)
+}
@@ -1,112 +1,283 @@
 package repository
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"go.uber.org/zap"
-	"strings"
+	"sort"
+	"time"
 
 	"github.com/redis/go-redis/v9"
-	"project/internal/DTOs"
 	"project/internal/core/entities"
 	"project/internal/infrastructure/cache"
 	"project/internal/infrastructure/logging"
 )
 
+// ErrPlaylistNotCached indica que o resumo da playlist não está na listagem do usuário.
+var ErrPlaylistNotCached = errors.New("playlist not found")
+
+// ErrVideosNotCached indica que os vídeos da playlist ainda não foram carregados no cache.
+var ErrVideosNotCached = errors.New("vídeos da playlist não estão em cache")
+
+// DefaultCacheTTL é o tempo máximo que uma listagem ou os vídeos de uma playlist ficam no Redis.
+const DefaultCacheTTL = 24 * time.Hour
+
 type playlistRepositoryRedis struct {
 	client cache.RedisCacheInterface
+	videos VideoRepositoryRedisInterface
+	codec  CacheCodec
+	ttl    time.Duration
 }
 
 type PlaylistRepositoryRedisInterface interface {
-	GetPlaylistById(playlistId string, userId string) (entities.PlaylistInterface, error)
-	SavePlaylist(userId string, playlist entities.PlaylistInterface) error
-	SaveAllPlaylists(userId string, playlists []entities.PlaylistInterface) error
-	DeletePlaylist(playlistId string) error
-	GetAllPlaylistsByUserID(userId string) ([]entities.PlaylistInterface, error)
+	GetPlaylistById(ctx context.Context, playlistId string, userId string) (entities.PlaylistInterface, error)
+	SavePlaylist(ctx context.Context, userId string, playlist entities.PlaylistInterface) error
+	SaveAllPlaylists(ctx context.Context, userId string, playlists []entities.PlaylistInterface) error
+	DeletePlaylist(ctx context.Context, userId, playlistId string) error
+	GetAllPlaylistsByUserID(ctx context.Context, userId string) ([]entities.PlaylistInterface, error)
+	// Os vídeos ficam em cache por usuário: quem não carregou a playlist com a própria conta
+	// não lê o que outro usuário guardou dela.
+	GetPlaylistVideos(ctx context.Context, userId, playlistId string) ([]entities.VideoInterface, error)
+	SavePlaylistVideos(ctx context.Context, userId, playlistId string, videos []entities.VideoInterface) error
+	PlaylistsFetchedAt(ctx context.Context, userId string) (time.Time, error)
+	VideosFetchedAt(ctx context.Context, userId, playlistId string) (time.Time, error)
+	InvalidatePlaylists(ctx context.Context, userId string) error
+	InvalidatePlaylistVideos(ctx context.Context, userId, playlistId string) error
+	DescribeUserCache(ctx context.Context, userId string) (UserCacheInfo, error)
+	EvictUser(ctx context.Context, userId string) error
 }
 
-func NewPlaylistRepositoryRedis(client cache.RedisCacheInterface) PlaylistRepositoryRedisInterface {
-	return &playlistRepositoryRedis{client: client}
+// NewPlaylistRepositoryRedis cria o repositório. Toda listagem e todo conjunto de vídeos
+// salvo expira depois de ttl (DefaultCacheTTL quando zero). A playlist guarda só os ids dos
+// vídeos; os metadados ficam no cache compartilhado de videos. Os registros são gravados
+// com codec (JSON quando nil).
+func NewPlaylistRepositoryRedis(client cache.RedisCacheInterface, videos VideoRepositoryRedisInterface, codec CacheCodec, ttl time.Duration) PlaylistRepositoryRedisInterface {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &playlistRepositoryRedis{client: client, videos: videos, codec: codecOrDefault(codec), ttl: ttl}
 }
 
-func (pr *playlistRepositoryRedis) GetPlaylistById(playlistId, userId string) (entities.PlaylistInterface, error) {
-	key := "playlists:" + userId
-	var dto DTOs.PlaylistRedisDTO
+func (pr *playlistRepositoryRedis) GetPlaylistById(ctx context.Context, playlistId, userId string) (entities.PlaylistInterface, error) {
+	key := playlistsKey(userId)
 
-	data, err := pr.client.HGet(key, playlistId)
+	data, err := pr.client.HGet(ctx, key, playlistId)
 	if err != nil {
 		logging.Error("GetPlaylistById - playlist_repository_redis - ln37", zap.String("Error", err.Error()))
 		if errors.Is(err, redis.Nil) {
-			return nil, errors.New("playlist not found")
+			return nil, ErrPlaylistNotCached
 		}
 		return nil, err
 	}
 
-	if err = json.Unmarshal([]byte(data.(string)), &dto); err != nil {
-		return nil, err
+	playlist, err := pr.codec.DecodePlaylist([]byte(data.(string)))
+	return playlist, err
+}
+
+// SavePlaylist atualiza o resumo da playlist na listagem do usuário e, se ela vier com
+// vídeos, guarda os vídeos na chave própria da playlist. Sem listagem em cache o resumo não é
+// gravado: um hash criado aqui pareceria a listagem completa, sem TTL e sem a marcação da busca.
+func (pr *playlistRepositoryRedis) SavePlaylist(ctx context.Context, userId string, playlist entities.PlaylistInterface) error {
+	fetchedAt, err := pr.PlaylistsFetchedAt(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if !fetchedAt.IsZero() {
+		key := playlistsKey(userId)
+		data, err := pr.codec.EncodePlaylist(playlist)
+		if err != nil {
+			return err
+		}
+		if err := pr.client.HSet(ctx, key, playlist.Id(), string(data)); err != nil {
+			return err
+		}
+		// Uma listagem vazia não tem hash; o HSet acabou de criá-lo sem TTL.
+		if err := pr.client.Expire(ctx, key, pr.ttl); err != nil {
+			return err
+		}
+	}
+
+	if len(playlist.Videos()) > 0 {
+		if err := pr.SavePlaylistVideos(ctx, userId, playlist.Id(), playlist.Videos()); err != nil {
+			return err
+		}
 	}
-	return dto.ToEntity(), nil
+	return nil
 }
 
-func (pr *playlistRepositoryRedis) SavePlaylist(userId string, playlist entities.PlaylistInterface) error {
-	key := "playlists:" + userId
-	data, err := json.Marshal(playlist)
+// GetPlaylistVideos monta os vídeos da playlist a partir dos ids guardados. Se algum vídeo
+// saiu do cache compartilhado, a lista inteira conta como ausente e é buscada de novo.
+func (pr *playlistRepositoryRedis) GetPlaylistVideos(ctx context.Context, userId, playlistId string) ([]entities.VideoInterface, error) {
+	data, err := pr.client.Get(ctx, videosKey(userId, playlistId))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrVideosNotCached
+		}
+		return nil, err
+	}
+
+	ids, legacy, err := pr.codec.DecodePlaylistVideos([]byte(data.(string)))
+	if err != nil {
+		logging.Info("Vídeos da playlist em formato incompatível no cache", zap.String("playlist_id", playlistId), zap.Error(err))
+		return nil, ErrVideosNotCached
+	}
+	// Registros antigos ainda trazem os vídeos completos.
+	if legacy != nil {
+		return legacy, nil
+	}
+
+	found, err := pr.videos.GetVideos(ctx, ids)
 	if err != nil {
+		return nil, err
+	}
+
+	videos := make([]entities.VideoInterface, len(ids))
+	for i, id := range ids {
+		video, ok := found[id]
+		if !ok {
+			return nil, ErrVideosNotCached
+		}
+		videos[i] = video
+	}
+	return videos, nil
+}
+
+// SavePlaylistVideos guarda os metadados no cache compartilhado e, na chave da playlist, só a ordem dos ids.
+func (pr *playlistRepositoryRedis) SavePlaylistVideos(ctx context.Context, userId, playlistId string, videos []entities.VideoInterface) error {
+	if err := pr.videos.SaveVideos(ctx, videos); err != nil {
 		return err
 	}
 
-	if err := pr.client.HSet(key, playlist.Id(), string(data)); err != nil {
+	ids := make([]string, len(videos))
+	for i, video := range videos {
+		ids[i] = video.Id()
+	}
+	data, err := pr.codec.EncodePlaylistVideos(ids)
+	if err != nil {
 		return err
 	}
+	if err := pr.client.Set(ctx, videosKey(userId, playlistId), string(data), pr.ttl); err != nil {
+		return err
+	}
+	return pr.markFetched(ctx, videosKey(userId, playlistId))
+}
+
+func playlistsKey(userId string) string {
+	return "playlists:" + userId
+}
 
-	return pr.client.HSet("playlistIndex", playlist.Id(), userId)
+const videosKeyPrefix = "playlist_videos:"
+
+func videosKey(userId, playlistId string) string {
+	return videosKeyPrefix + userId + ":" + playlistId
+}
+
+func fetchedAtKey(key string) string {
+	return key + ":fetched_at"
 }
 
-func (pr *playlistRepositoryRedis) SaveAllPlaylists(userId string, playlists []entities.PlaylistInterface) error {
+// SaveAllPlaylists substitui a listagem do usuário inteira, para que playlists removidas
+// no YouTube também saiam do cache, e marca o instante da busca. A troca é atômica: uma
+// leitura concorrente nunca vê a listagem pela metade.
+func (pr *playlistRepositoryRedis) SaveAllPlaylists(ctx context.Context, userId string, playlists []entities.PlaylistInterface) error {
+	fields := make(map[string]string, len(playlists))
 	for _, playlist := range playlists {
-		if err := pr.SavePlaylist(userId, playlist); err != nil {
+		data, err := pr.codec.EncodePlaylist(playlist)
+		if err != nil {
 			return err
 		}
+		fields[playlist.Id()] = string(data)
 	}
-	return nil
+	if err := pr.client.HReplace(ctx, playlistsKey(userId), fields, pr.ttl); err != nil {
+		return err
+	}
+
+	for _, playlist := range playlists {
+		if len(playlist.Videos()) > 0 {
+			if err := pr.SavePlaylistVideos(ctx, userId, playlist.Id(), playlist.Videos()); err != nil {
+				return err
+			}
+		}
+	}
+	return pr.markFetched(ctx, playlistsKey(userId))
 }
 
-func (pr *playlistRepositoryRedis) DeletePlaylist(playlistId string) error {
-	userId, err := pr.client.HGet("playlistIndex", playlistId)
-	if err != nil {
+func (pr *playlistRepositoryRedis) PlaylistsFetchedAt(ctx context.Context, userId string) (time.Time, error) {
+	return pr.fetchedAt(ctx, playlistsKey(userId))
+}
+
+func (pr *playlistRepositoryRedis) VideosFetchedAt(ctx context.Context, userId, playlistId string) (time.Time, error) {
+	return pr.fetchedAt(ctx, videosKey(userId, playlistId))
+}
+
+// InvalidatePlaylists descarta a listagem do usuário; a próxima leitura busca de novo no YouTube.
+func (pr *playlistRepositoryRedis) InvalidatePlaylists(ctx context.Context, userId string) error {
+	if err := pr.client.Delete(ctx, playlistsKey(userId)); err != nil {
 		return err
 	}
-	if userId == "" {
-		return errors.New("playlist not found in global index")
-	}
+	return pr.client.Delete(ctx, fetchedAtKey(playlistsKey(userId)))
+}
 
-	key := "playlists:" + userId.(string)
-	if err := pr.client.HDel(key, playlistId); err != nil {
+func (pr *playlistRepositoryRedis) InvalidatePlaylistVideos(ctx context.Context, userId, playlistId string) error {
+	if err := pr.client.Delete(ctx, videosKey(userId, playlistId)); err != nil {
 		return err
 	}
+	return pr.client.Delete(ctx, fetchedAtKey(videosKey(userId, playlistId)))
+}
 
-	return pr.client.HDel("playlistIndex", playlistId)
+// markFetched grava quando o conteúdo de key foi buscado no YouTube; expira junto com ele.
+func (pr *playlistRepositoryRedis) markFetched(ctx context.Context, key string) error {
+	return pr.client.Set(ctx, fetchedAtKey(key), time.Now().UTC().Format(time.RFC3339Nano), pr.ttl)
 }
 
-func (pr *playlistRepositoryRedis) GetAllPlaylistsByUserID(userId string) ([]entities.PlaylistInterface, error) {
-	key := "playlists:" + userId
-	data, err := pr.client.HGetAll(key)
+// fetchedAt retorna o instante zero quando o conteúdo não está em cache ou não tem marcação.
+func (pr *playlistRepositoryRedis) fetchedAt(ctx context.Context, key string) (time.Time, error) {
+	value, err := pr.client.Get(ctx, fetchedAtKey(key))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	fetchedAt, err := time.Parse(time.RFC3339Nano, value.(string))
+	if err != nil {
+		return time.Time{}, nil
+	}
+	return fetchedAt, nil
+}
+
+// DeletePlaylist tira a playlist da listagem do usuário e descarta os vídeos dela em cache.
+// O cache de outros usuários que tenham a mesma playlist não é tocado.
+func (pr *playlistRepositoryRedis) DeletePlaylist(ctx context.Context, userId, playlistId string) error {
+	if err := pr.client.HDel(ctx, playlistsKey(userId), playlistId); err != nil {
+		return err
+	}
+	return pr.InvalidatePlaylistVideos(ctx, userId, playlistId)
+}
+
+func (pr *playlistRepositoryRedis) GetAllPlaylistsByUserID(ctx context.Context, userId string) ([]entities.PlaylistInterface, error) {
+	key := playlistsKey(userId)
+	data, err := pr.client.HGetAll(ctx, key)
 	if err != nil {
 		return nil, err
 	}
 
 	var playlists []entities.PlaylistInterface
-	for _, value := range data {
-		var dto DTOs.PlaylistRedisDTO
-		if err := json.Unmarshal([]byte(value), &dto); err != nil {
-			logging.Error("Erro ao deserializar playlist", zap.Error(err))
-			continue
-		}
-		if strings.TrimSpace(dto.Id) == "" || strings.TrimSpace(dto.Title) == "" {
+	for field, value := range data {
+		// Versões antigas são atualizadas na leitura; as incompatíveis ficam de fora até o migrate-cache removê-las.
+		playlist, err := pr.codec.DecodePlaylist([]byte(value))
+		if err != nil {
+			logging.Error("Erro ao deserializar playlist", zap.String("playlist_id", field), zap.Error(err))
 			continue
 		}
-		playlists = append(playlists, dto.ToEntity())
+		playlists = append(playlists, playlist)
 	}
 
+	// HGETALL não garante ordem; ordenar pelo id mantém a resposta estável entre chamadas.
+	sort.Slice(playlists, func(i, j int) bool {
+		return playlists[i].Id() < playlists[j].Id()
+	})
+
 	return playlists, nil
 }
@@ -1,27 +1,33 @@
 package repository_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"path"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"project/internal/DTOs"
 	"project/internal/core/entities"
 	"project/internal/infrastructure/repository"
 )
 
 type fakeCache struct {
-	data map[string]string
+	data   map[string]string
+	hashes map[string]map[string]string
 }
 
 func newFakeCache() *fakeCache {
 	return &fakeCache{
-		data: make(map[string]string),
+		data:   make(map[string]string),
+		hashes: make(map[string]map[string]string),
 	}
 }
 
-func (f *fakeCache) Set(key string, value interface{}, expiration time.Duration) error {
+func (f *fakeCache) Set(_ context.Context, key string, value interface{}, expiration time.Duration) error {
 	switch v := value.(type) {
 	case string:
 		f.data[key] = v
@@ -35,22 +41,122 @@ func (f *fakeCache) Set(key string, value interface{}, expiration time.Duration)
 	return nil
 }
 
-func (f *fakeCache) Get(key string) (interface{}, error) {
+func (f *fakeCache) Get(_ context.Context, key string) (interface{}, error) {
 	val, ok := f.data[key]
 	if !ok {
-		return nil, errors.New("redis: nil")
+		return nil, redis.Nil
 	}
 	return val, nil
 }
 
-func (f *fakeCache) Delete(key string) error {
+func (f *fakeCache) MGet(_ context.Context, keys []string) ([]interface{}, error) {
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		if val, ok := f.data[key]; ok {
+			values[i] = val
+		}
+	}
+	return values, nil
+}
+
+func (f *fakeCache) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	for key, value := range values {
+		if err := f.Set(ctx, key, value, expiration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeCache) Delete(_ context.Context, key string) error {
 	delete(f.data, key)
+	delete(f.hashes, key)
+	return nil
+}
+
+func (f *fakeCache) HGet(_ context.Context, key string, field string) (interface{}, error) {
+	val, ok := f.hashes[key][field]
+	if !ok {
+		return nil, redis.Nil
+	}
+	return val, nil
+}
+
+func (f *fakeCache) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	result := make(map[string]string, len(f.hashes[key]))
+	for field, val := range f.hashes[key] {
+		result[field] = val
+	}
+	return result, nil
+}
+
+func (f *fakeCache) HSet(_ context.Context, key string, field string, value interface{}) error {
+	if f.hashes[key] == nil {
+		f.hashes[key] = make(map[string]string)
+	}
+	switch v := value.(type) {
+	case string:
+		f.hashes[key][field] = v
+	default:
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		f.hashes[key][field] = string(bytes)
+	}
 	return nil
 }
 
+func (f *fakeCache) HDel(_ context.Context, key string, field string) error {
+	delete(f.hashes[key], field)
+	return nil
+}
+
+func (f *fakeCache) HIncrBy(_ context.Context, key string, field string, incr int64) (int64, error) {
+	if f.hashes[key] == nil {
+		f.hashes[key] = make(map[string]string)
+	}
+	current, _ := strconv.ParseInt(f.hashes[key][field], 10, 64)
+	current += incr
+	f.hashes[key][field] = strconv.FormatInt(current, 10)
+	return current, nil
+}
+
+func (f *fakeCache) Expire(_ context.Context, key string, expiration time.Duration) error {
+	return nil
+}
+
+func (f *fakeCache) HReplace(_ context.Context, key string, fields map[string]string, expiration time.Duration) error {
+	delete(f.hashes, key)
+	if len(fields) == 0 {
+		return nil
+	}
+	f.hashes[key] = make(map[string]string, len(fields))
+	for field, value := range fields {
+		f.hashes[key][field] = value
+	}
+	return nil
+}
+
+func (f *fakeCache) Keys(_ context.Context, pattern string) ([]string, error) {
+	var keys []string
+	for key := range f.data {
+		if ok, _ := path.Match(pattern, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	for key := range f.hashes {
+		if ok, _ := path.Match(pattern, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
 func TestSaveAndGetAllPlaylistsByUserID(t *testing.T) {
+	ctx := context.Background()
 	fc := newFakeCache()
-	repo := repository.NewPlaylistRepositoryRedis(fc)
+	repo := repository.NewPlaylistRepositoryRedis(fc, repository.NewVideoRepositoryRedis(fc, nil, 0), nil, 0)
 
 	userID := "user123"
 
@@ -58,12 +164,12 @@ func TestSaveAndGetAllPlaylistsByUserID(t *testing.T) {
 	playlist2 := entities.NewPlaylist("playlist2", "channel1", "Playlist 2", "Description 2", time.Now(), nil)
 	playlists := []entities.PlaylistInterface{playlist1, playlist2}
 
-	err := repo.SaveAllPlaylists(userID, playlists)
+	err := repo.SaveAllPlaylists(ctx, userID, playlists)
 	if err != nil {
 		t.Fatalf("Erro ao salvar playlists: %v", err)
 	}
 
-	retrieved, err := repo.GetAllPlaylistsByUserID(userID)
+	retrieved, err := repo.GetAllPlaylistsByUserID(ctx, userID)
 	if err != nil {
 		t.Fatalf("Erro ao recuperar playlists: %v", err)
 	}
@@ -78,13 +184,24 @@ func TestSaveAndGetAllPlaylistsByUserID(t *testing.T) {
 }
 
 func TestSaveGetAndDeletePlaylist(t *testing.T) {
+	ctx := context.Background()
 	fc := newFakeCache()
-	repo := repository.NewPlaylistRepositoryRedis(fc)
+	repo := repository.NewPlaylistRepositoryRedis(fc, repository.NewVideoRepositoryRedis(fc, nil, 0), nil, 0)
 
 	userID := "user123"
 	playlist := entities.NewPlaylist("playlist1", "channel1", "Playlist 1", "Description 1", time.Now(), nil)
 
-	err := repo.SavePlaylist(userID, playlist)
+	if err := repo.SavePlaylist(ctx, userID, playlist); err != nil {
+		t.Fatalf("Erro ao salvar a playlist: %v", err)
+	}
+	if _, err := repo.GetPlaylistById(ctx, "playlist1", userID); !errors.Is(err, repository.ErrPlaylistNotCached) {
+		t.Fatalf("Sem listagem em cache o resumo não deveria ser gravado, obtido %v", err)
+	}
+
+	if err := repo.SaveAllPlaylists(ctx, userID, nil); err != nil {
+		t.Fatalf("Erro ao salvar a listagem vazia: %v", err)
+	}
+	err := repo.SavePlaylist(ctx, userID, playlist)
 	if err != nil {
 		t.Fatalf("Erro ao salvar a playlist: %v", err)
 	}
@@ -96,7 +213,7 @@ func TestSaveGetAndDeletePlaylist(t *testing.T) {
 	}
 	fc.data[key] = string(data)
 
-	retrieved, err := repo.GetPlaylistById("playlist1")
+	retrieved, err := repo.GetPlaylistById(ctx, "playlist1", userID)
 	if err != nil {
 		t.Fatalf("Erro ao recuperar a playlist: %v", err)
 	}
@@ -105,13 +222,148 @@ func TestSaveGetAndDeletePlaylist(t *testing.T) {
 		t.Errorf("Esperado título '%s', obtido '%s'", playlist.Title(), retrieved.Title())
 	}
 
-	err = repo.DeletePlaylist("playlist1")
+	if err := repo.DeletePlaylist(ctx, "user456", "playlist1"); err != nil {
+		t.Fatalf("Erro ao deletar a playlist de outro usuário: %v", err)
+	}
+	if _, err := repo.GetPlaylistById(ctx, "playlist1", userID); err != nil {
+		t.Fatalf("A remoção por outro usuário não deveria tocar a listagem de %s: %v", userID, err)
+	}
+
+	err = repo.DeletePlaylist(ctx, userID, "playlist1")
 	if err != nil {
 		t.Fatalf("Erro ao deletar a playlist: %v", err)
 	}
 
-	_, err = repo.GetPlaylistById("playlist1")
+	_, err = repo.GetPlaylistById(ctx, "playlist1", userID)
 	if err == nil {
 		t.Errorf("Esperado erro ao buscar playlist deletada, mas não ocorreu")
 	}
 }
+
+func TestPlaylistVideosAreCachedSeparately(t *testing.T) {
+	ctx := context.Background()
+	fc := newFakeCache()
+	repo := repository.NewPlaylistRepositoryRedis(fc, repository.NewVideoRepositoryRedis(fc, nil, 0), nil, 0)
+
+	userID := "user123"
+	videos := []entities.VideoInterface{
+		entities.NewVideo("video1", "Video 1", "channel1", "pt", time.Now(), time.Minute),
+		entities.NewVideo("video2", "Video 2", "channel1", "pt", time.Now(), time.Minute),
+	}
+	playlist := entities.NewPlaylist("playlist1", "channel1", "Playlist 1", "Description 1", time.Now(), videos)
+
+	if _, err := repo.GetPlaylistVideos(ctx, "user123", "playlist1"); !errors.Is(err, repository.ErrVideosNotCached) {
+		t.Fatalf("Esperado ErrVideosNotCached antes de salvar, obtido %v", err)
+	}
+
+	if err := repo.SaveAllPlaylists(ctx, userID, nil); err != nil {
+		t.Fatalf("Erro ao salvar a listagem vazia: %v", err)
+	}
+	if err := repo.SavePlaylist(ctx, userID, playlist); err != nil {
+		t.Fatalf("Erro ao salvar a playlist: %v", err)
+	}
+
+	summaries, err := repo.GetAllPlaylistsByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("Erro ao recuperar playlists: %v", err)
+	}
+	if len(summaries) != 1 || len(summaries[0].Videos()) != 0 {
+		t.Fatalf("O resumo não deveria carregar vídeos, obtido %+v", summaries)
+	}
+	if summaries[0].ItemCount() != 2 {
+		t.Errorf("Esperado itemCount 2 no resumo, obtido %d", summaries[0].ItemCount())
+	}
+
+	cached, err := repo.GetPlaylistVideos(ctx, "user123", "playlist1")
+	if err != nil {
+		t.Fatalf("Erro ao recuperar vídeos: %v", err)
+	}
+	if len(cached) != 2 || cached[0].Id() != "video1" || cached[1].Id() != "video2" {
+		t.Errorf("Vídeos em cache não correspondem ao esperado: %+v", cached)
+	}
+	if _, err := repo.GetPlaylistVideos(ctx, "user456", "playlist1"); !errors.Is(err, repository.ErrVideosNotCached) {
+		t.Errorf("Outro usuário não deveria ler os vídeos em cache da playlist, obtido %v", err)
+	}
+
+	if err := repo.DeletePlaylist(ctx, userID, "playlist1"); err != nil {
+		t.Fatalf("Erro ao deletar a playlist: %v", err)
+	}
+	if _, err := repo.GetPlaylistVideos(ctx, "user123", "playlist1"); !errors.Is(err, repository.ErrVideosNotCached) {
+		t.Errorf("Vídeos deveriam ser removidos junto com a playlist, obtido %v", err)
+	}
+}
+
+func TestFetchedAtMarksAndInvalidation(t *testing.T) {
+	ctx := context.Background()
+	fc := newFakeCache()
+	repo := repository.NewPlaylistRepositoryRedis(fc, repository.NewVideoRepositoryRedis(fc, nil, 0), nil, time.Hour)
+
+	userID := "user123"
+	fetchedAt, err := repo.PlaylistsFetchedAt(ctx, userID)
+	if err != nil || !fetchedAt.IsZero() {
+		t.Fatalf("Esperado instante zero antes da primeira busca, obtido %v (%v)", fetchedAt, err)
+	}
+
+	before := time.Now()
+	playlists := []entities.PlaylistInterface{
+		entities.NewPlaylist("playlist1", "channel1", "Playlist 1", "Description 1", time.Now(), nil),
+	}
+	if err := repo.SaveAllPlaylists(ctx, userID, playlists); err != nil {
+		t.Fatalf("Erro ao salvar playlists: %v", err)
+	}
+
+	fetchedAt, err = repo.PlaylistsFetchedAt(ctx, userID)
+	if err != nil || fetchedAt.Before(before) {
+		t.Fatalf("A listagem deveria ser marcada com o instante da busca, obtido %v (%v)", fetchedAt, err)
+	}
+
+	if err := repo.InvalidatePlaylists(ctx, userID); err != nil {
+		t.Fatalf("Erro ao invalidar playlists: %v", err)
+	}
+	cached, _ := repo.GetAllPlaylistsByUserID(ctx, userID)
+	fetchedAt, _ = repo.PlaylistsFetchedAt(ctx, userID)
+	if len(cached) != 0 || !fetchedAt.IsZero() {
+		t.Errorf("A invalidação deveria remover a listagem e a marcação, obtido %d playlists e %v", len(cached), fetchedAt)
+	}
+}
+
+func TestDescribeAndEvictUserCache(t *testing.T) {
+	ctx := context.Background()
+	fc := newFakeCache()
+	repo := repository.NewPlaylistRepositoryRedis(fc, repository.NewVideoRepositoryRedis(fc, nil, 0), nil, 0)
+
+	userID := "user123"
+	videos := []entities.VideoInterface{entities.NewVideo("video1", "Video 1", "channel1", "pt", time.Now(), time.Minute)}
+	playlists := []entities.PlaylistInterface{
+		entities.NewPlaylist("playlist1", "channel1", "Playlist 1", "", time.Now(), videos),
+		entities.NewPlaylist("playlist2", "channel1", "Playlist 2", "", time.Now(), nil),
+	}
+	if err := repo.SaveAllPlaylists(ctx, userID, playlists); err != nil {
+		t.Fatalf("Erro ao salvar playlists: %v", err)
+	}
+
+	info, err := repo.DescribeUserCache(ctx, userID)
+	if err != nil {
+		t.Fatalf("Erro ao descrever o cache: %v", err)
+	}
+	if len(info.Playlists) != 2 || info.FetchedAt.IsZero() || info.TotalBytes == 0 {
+		t.Fatalf("Descrição inesperada: %+v", info)
+	}
+	if !info.Playlists[0].VideosCached || info.Playlists[1].VideosCached || info.Playlists[0].Title != "Playlist 1" {
+		t.Errorf("Só playlist1 deveria ter vídeos em cache: %+v", info.Playlists)
+	}
+
+	if err := repo.EvictUser(ctx, userID); err != nil {
+		t.Fatalf("Erro ao remover o cache do usuário: %v", err)
+	}
+	info, _ = repo.DescribeUserCache(ctx, userID)
+	if len(info.Playlists) != 0 || !info.FetchedAt.IsZero() {
+		t.Errorf("O cache do usuário deveria estar vazio, obtido %+v", info)
+	}
+	if _, err := repo.GetPlaylistVideos(ctx, "user123", "playlist1"); !errors.Is(err, repository.ErrVideosNotCached) {
+		t.Errorf("Os vídeos das playlists do usuário deveriam ser removidos, obtido %v", err)
+	}
+	if _, ok := fc.data["video:video1"]; !ok {
+		t.Error("Os metadados compartilhados do vídeo não deveriam ser removidos")
+	}
+}
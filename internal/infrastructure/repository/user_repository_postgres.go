@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"gorm.io/gorm"
 	"project/internal/DTOs"
@@ -9,11 +10,11 @@ import (
 )
 
 type UserRepositoryInterface interface {
-	CreateUser(user entities.UserInterface) error
-	GetUserByID(id string) (entities.UserInterface, error)
-	GetUserByEmail(email string) (entities.UserInterface, error)
-	UpdateUser(user entities.UserInterface) error
-	DeleteUser(id string) error
+	CreateUser(ctx context.Context, user entities.UserInterface) error
+	GetUserByID(ctx context.Context, id string) (entities.UserInterface, error)
+	GetUserByEmail(ctx context.Context, email string) (entities.UserInterface, error)
+	UpdateUser(ctx context.Context, user entities.UserInterface) error
+	DeleteUser(ctx context.Context, id string) error
 }
 
 type userRepositoryPostgres struct {
@@ -26,16 +27,16 @@ func NewUserRepositoryPostgres(db *gorm.DB) UserRepositoryInterface {
 	}
 }
 
-func (r *userRepositoryPostgres) CreateUser(user entities.UserInterface) error {
+func (r *userRepositoryPostgres) CreateUser(ctx context.Context, user entities.UserInterface) error {
 	userDTO := DTOs.UserFromEntity(user)
-	result := r.db.Create(&userDTO)
+	result := r.db.WithContext(ctx).Create(&userDTO)
 
 	return result.Error
 }
 
-func (r *userRepositoryPostgres) GetUserByID(id string) (entities.UserInterface, error) {
+func (r *userRepositoryPostgres) GetUserByID(ctx context.Context, id string) (entities.UserInterface, error) {
 	var user DTOs.UserDTO
-	result := r.db.First(&user, "id = ?", id)
+	result := r.db.WithContext(ctx).First(&user, "id = ?", id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		logging.Info("Usuario nao encontrado - user_repository_postgres - GetUserByID - LN40")
 		return nil, nil
@@ -44,9 +45,9 @@ func (r *userRepositoryPostgres) GetUserByID(id string) (entities.UserInterface,
 	return user.ToEntity(), result.Error
 }
 
-func (r *userRepositoryPostgres) GetUserByEmail(email string) (entities.UserInterface, error) {
+func (r *userRepositoryPostgres) GetUserByEmail(ctx context.Context, email string) (entities.UserInterface, error) {
 	var user DTOs.UserDTO
-	result := r.db.First(&user, "email = ?", email)
+	result := r.db.WithContext(ctx).First(&user, "email = ?", email)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -54,14 +55,14 @@ func (r *userRepositoryPostgres) GetUserByEmail(email string) (entities.UserInte
 	return user.ToEntity(), result.Error
 }
 
-func (r *userRepositoryPostgres) UpdateUser(user entities.UserInterface) error {
+func (r *userRepositoryPostgres) UpdateUser(ctx context.Context, user entities.UserInterface) error {
 	userDTO := DTOs.UserFromEntity(user)
-	result := r.db.Save(&userDTO)
+	result := r.db.WithContext(ctx).Save(&userDTO)
 	return result.Error
 }
 
-func (r *userRepositoryPostgres) DeleteUser(id string) error {
-	result := r.db.Delete(&DTOs.UserDTO{}, "id = ?", id)
+func (r *userRepositoryPostgres) DeleteUser(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Delete(&DTOs.UserDTO{}, "id = ?", id)
 	return result.Error
 
 }
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"project/internal/core/entities"
+	"project/internal/infrastructure/cache"
+	"project/internal/infrastructure/logging"
+)
+
+// DefaultVideoCacheTTL é o tempo que os metadados de um vídeo ficam no Redis. Eles mudam pouco
+// e são os mesmos para todos os usuários, então vivem mais que as listagens.
+const DefaultVideoCacheTTL = 7 * 24 * time.Hour
+
+type videoRepositoryRedis struct {
+	client cache.RedisCacheInterface
+	codec  CacheCodec
+	ttl    time.Duration
+}
+
+// VideoRepositoryRedisInterface guarda os metadados de cada vídeo em video:<id>, compartilhados
+// entre usuários e playlists.
+type VideoRepositoryRedisInterface interface {
+	// GetVideos devolve os vídeos encontrados no cache, indexados pelo id; os ausentes ficam de fora.
+	GetVideos(ctx context.Context, videoIds []string) (map[string]entities.VideoInterface, error)
+	SaveVideos(ctx context.Context, videos []entities.VideoInterface) error
+}
+
+func NewVideoRepositoryRedis(client cache.RedisCacheInterface, codec CacheCodec, ttl time.Duration) VideoRepositoryRedisInterface {
+	if ttl <= 0 {
+		ttl = DefaultVideoCacheTTL
+	}
+	return &videoRepositoryRedis{client: client, codec: codecOrDefault(codec), ttl: ttl}
+}
+
+func videoKey(videoId string) string {
+	return "video:" + videoId
+}
+
+// GetVideos lê todas as chaves em uma única ida ao Redis.
+func (vr *videoRepositoryRedis) GetVideos(ctx context.Context, videoIds []string) (map[string]entities.VideoInterface, error) {
+	found := make(map[string]entities.VideoInterface, len(videoIds))
+	if len(videoIds) == 0 {
+		return found, nil
+	}
+
+	keys := make([]string, len(videoIds))
+	for i, id := range videoIds {
+		keys[i] = videoKey(id)
+	}
+	values, err := vr.client.MGet(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		id := videoIds[i]
+		// Registros incompatíveis contam como ausentes e são sobrescritos na próxima busca.
+		video, err := vr.codec.DecodeVideo([]byte(data))
+		if err != nil {
+			logging.Error("Erro ao deserializar vídeo do cache", zap.String("video_id", id), zap.Error(err))
+			continue
+		}
+		found[id] = video
+	}
+	return found, nil
+}
+
+// SaveVideos grava os vídeos em um único pipeline.
+func (vr *videoRepositoryRedis) SaveVideos(ctx context.Context, videos []entities.VideoInterface) error {
+	values := make(map[string]interface{}, len(videos))
+	for _, video := range videos {
+		data, err := vr.codec.EncodeVideo(video)
+		if err != nil {
+			return err
+		}
+		values[videoKey(video.Id())] = string(data)
+	}
+	return vr.client.SetMany(ctx, values, vr.ttl)
+}
@@ -0,0 +1,90 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"project/internal/core/entities"
+	"project/internal/infrastructure/repository"
+)
+
+func TestPlaylistsShareVideoMetadata(t *testing.T) {
+	ctx := context.Background()
+	fc := newFakeCache()
+	videos := repository.NewVideoRepositoryRedis(fc, nil, 0)
+	repo := repository.NewPlaylistRepositoryRedis(fc, videos, nil, 0)
+
+	shared := entities.NewVideo("video1", "Video 1", "channel1", "pt", time.Now(), time.Minute)
+	if err := repo.SavePlaylistVideos(ctx, "user123", "playlist1", []entities.VideoInterface{shared}); err != nil {
+		t.Fatalf("Erro ao salvar vídeos: %v", err)
+	}
+	if err := repo.SavePlaylistVideos(ctx, "user123", "playlist2", []entities.VideoInterface{shared, shared}); err != nil {
+		t.Fatalf("Erro ao salvar vídeos: %v", err)
+	}
+
+	if fc.data["playlist_videos:user123:playlist2"] != `{"v":2,"video_ids":["video1","video1"]}` {
+		t.Errorf("A playlist deveria guardar só os ids, obtido %s", fc.data["playlist_videos:user123:playlist2"])
+	}
+
+	found, err := videos.GetVideos(ctx, []string{"video1", "video2"})
+	if err != nil {
+		t.Fatalf("Erro ao ler vídeos: %v", err)
+	}
+	if len(found) != 1 || found["video1"].Title() != "Video 1" {
+		t.Errorf("Esperado apenas video1 no cache compartilhado, obtido %+v", found)
+	}
+
+	// Sem os metadados de um dos vídeos, a lista da playlist precisa ser buscada de novo.
+	delete(fc.data, "video:video1")
+	if _, err := repo.GetPlaylistVideos(ctx, "user123", "playlist2"); !errors.Is(err, repository.ErrVideosNotCached) {
+		t.Errorf("Esperado ErrVideosNotCached sem os metadados do vídeo, obtido %v", err)
+	}
+}
+
+func TestCacheMigratorUpgradesAndPurges(t *testing.T) {
+	ctx := context.Background()
+	fc := newFakeCache()
+	fc.data["video:video1"] = `{"id":"video1","title":"Video 1","artist":"channel1","duration":60000000000}`
+	fc.data["video:broken"] = `{"v":99,"id":"broken"}`
+	fc.data["playlist_videos:user123:playlist1"] = `[{"id":"video2","title":"Video 2","artist":"channel2"}]`
+	fc.data["playlist_videos:playlist2"] = `{"v":2,"video_ids":["video1"]}`
+	fc.hashes["playlistIndex"] = map[string]string{"playlist1": "user123"}
+	fc.hashes["playlists:user123"] = map[string]string{
+		"playlist1": `{"id":"playlist1","title":"Playlist 1","itemCount":1}`,
+	}
+
+	migrator := repository.NewCacheMigrator(fc, nil)
+	report, err := migrator.Migrate(ctx, true)
+	if err != nil {
+		t.Fatalf("Erro no dry-run: %v", err)
+	}
+	if report.Upgraded != 3 || report.Purged != 3 || fc.data["video:broken"] == "" {
+		t.Fatalf("O dry-run deveria só contar, obtido %+v", report)
+	}
+
+	if _, err := migrator.Migrate(ctx, false); err != nil {
+		t.Fatalf("Erro na migração: %v", err)
+	}
+	if _, ok := fc.data["video:broken"]; ok {
+		t.Error("Registro de versão desconhecida deveria ser removido")
+	}
+	if _, ok := fc.data["playlist_videos:playlist2"]; ok {
+		t.Error("Vídeos em chave sem o usuário deveriam ser removidos")
+	}
+	if _, ok := fc.hashes["playlistIndex"]; ok {
+		t.Error("O índice global de playlists deveria ser removido")
+	}
+
+	repo := repository.NewPlaylistRepositoryRedis(fc, repository.NewVideoRepositoryRedis(fc, nil, 0), nil, 0)
+	videos, err := repo.GetPlaylistVideos(ctx, "user123", "playlist1")
+	if err != nil || len(videos) != 1 || videos[0].ChannelId() != "channel2" {
+		t.Fatalf("Vídeos antigos deveriam ir para o cache compartilhado, obtido %+v (%v)", videos, err)
+	}
+
+	again, err := migrator.Migrate(ctx, false)
+	if err != nil || again.Upgraded != 0 || again.Purged != 0 {
+		t.Errorf("Uma segunda passada não deveria alterar nada, obtido %+v (%v)", again, err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package requestctx guarda no contexto a identificação da requisição que originou uma
+// operação, para que ela acompanhe as ações adiadas até o consumidor da fila.
+package requestctx
+
+import "context"
+
+type key int
+
+const (
+	requestIdKey key = iota
+	idempotencyKey
+	jobIdKey
+)
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	if requestId == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIdKey, requestId)
+}
+
+// RequestId retorna "" quando ctx não veio de uma requisição.
+func RequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey).(string)
+	return requestId
+}
+
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKey, key)
+}
+
+// IdempotencyKey retorna a chave enviada pelo cliente ou herdada da ação reprocessada.
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey).(string)
+	return key
+}
+
+func WithJobId(ctx context.Context, jobId string) context.Context {
+	if jobId == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, jobIdKey, jobId)
+}
+
+// JobId retorna o job assíncrono do qual a operação faz parte, ou "" fora de um job.
+func JobId(ctx context.Context) string {
+	jobId, _ := ctx.Value(jobIdKey).(string)
+	return jobId
+}
@@ -0,0 +1,205 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/api/googleapi"
+	"project/internal/infrastructure/logging"
+)
+
+// Policy define quantas vezes e com que espera uma operação é repetida.
+//
+// Operações não idempotentes (inserções) só são repetidas em erros de limite de taxa,
+// em que o YouTube garante que a requisição foi recusada; um 5xx pode ter sido aplicado.
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Idempotent  bool
+}
+
+var (
+	readPolicy  = Policy{MaxAttempts: 5, BaseDelay: 500 * time.Millisecond, MaxDelay: 20 * time.Second, Idempotent: true}
+	writePolicy = Policy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second, Idempotent: false}
+)
+
+// DefaultPolicies são os limites por operação usados pelo serviço de playlists.
+func DefaultPolicies() map[string]Policy {
+	return map[string]Policy{
+		"get_all_playlists":      readPolicy,
+		"get_playlist":           readPolicy,
+		"get_playlist_video_ids": readPolicy,
+		"get_video_details":      readPolicy,
+		"create_playlist":        writePolicy,
+		"add_video_to_playlist":  writePolicy,
+		"update_playlist":        {MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second, Idempotent: true},
+		"delete_playlist":        {MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second, Idempotent: true},
+	}
+}
+
+// RetrierInterface executa uma chamada ao YouTube repetindo-a em erros transitórios.
+type RetrierInterface interface {
+	Do(ctx context.Context, operation string, fn func() error) error
+}
+
+type retrier struct {
+	policies map[string]Policy
+	fallback Policy
+}
+
+func NewRetrier(policies map[string]Policy, fallback Policy) RetrierInterface {
+	if fallback.MaxAttempts < 1 {
+		fallback = readPolicy
+	}
+	return &retrier{policies: policies, fallback: fallback}
+}
+
+func (r *retrier) Do(ctx context.Context, operation string, fn func() error) error {
+	policy, ok := r.policies[operation]
+	if !ok {
+		policy = r.fallback
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= policy.MaxAttempts || !policy.ShouldRetry(err) {
+			if attempt > 1 {
+				return fmt.Errorf("%w (após %d tentativas)", err, attempt)
+			}
+			return err
+		}
+
+		delay, ok := policy.Delay(attempt, err)
+		if !ok {
+			logging.Info("Retry-After maior que a espera máxima, desistindo",
+				zap.String("operation", operation), zap.Int("attempt", attempt), zap.Duration("max_delay", policy.MaxDelay), zap.Error(err))
+			return err
+		}
+
+		logging.Info("Retentando chamada ao YouTube",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", policy.MaxAttempts),
+			zap.Duration("delay", delay),
+			zap.Int("status", statusCode(err)),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// ShouldRetry informa se o erro é transitório para esta política.
+func (p Policy) ShouldRetry(err error) bool {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return false
+	}
+
+	if isRateLimited(gErr) {
+		return true
+	}
+
+	return p.Idempotent && (gErr.Code >= 500 || hasReason(gErr, "backendError", "internalError"))
+}
+
+// Delay calcula a espera antes da próxima tentativa: backoff exponencial com jitter completo,
+// respeitando o Retry-After enviado pela API. Retorna false quando o Retry-After excede MaxDelay.
+func (p Policy) Delay(attempt int, err error) (time.Duration, bool) {
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff <= 0 || backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	delay := rand.N(backoff) + 1
+
+	if retryAfter, ok := RetryAfter(err); ok {
+		if retryAfter > p.MaxDelay {
+			return 0, false
+		}
+		delay = max(delay, retryAfter)
+	}
+
+	return delay, true
+}
+
+// RetryAfter lê o cabeçalho Retry-After (em segundos ou como data HTTP) de um erro da API.
+func RetryAfter(err error) (time.Duration, bool) {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) || gErr.Header == nil {
+		return 0, false
+	}
+
+	value := gErr.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// IsTransient informa se o erro indica instabilidade da API (e não um erro de negócio):
+// 5xx, limite de taxa ou falha de rede antes de haver resposta. Cancelamentos e prazos
+// esgotados do lado do cliente, inclusive o *url.Error que os embrulha, não contam: dizem
+// respeito à requisição, não à saúde da API.
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		return Policy{Idempotent: true}.ShouldRetry(gErr)
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func isRateLimited(err *googleapi.Error) bool {
+	return err.Code == http.StatusTooManyRequests || hasReason(err, "rateLimitExceeded", "userRateLimitExceeded")
+}
+
+func hasReason(err *googleapi.Error, reasons ...string) bool {
+	for _, detail := range err.Errors {
+		for _, reason := range reasons {
+			if detail.Reason == reason {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func statusCode(err error) int {
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		return gErr.Code
+	}
+	return 0
+}
@@ -0,0 +1,119 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"project/internal/infrastructure/retry"
+)
+
+func apiError(code int, reason string) error {
+	err := &googleapi.Error{Code: code, Header: http.Header{}}
+	if reason != "" {
+		err.Errors = []googleapi.ErrorItem{{Reason: reason}}
+	}
+	return err
+}
+
+func fastRetrier(policy retry.Policy) retry.RetrierInterface {
+	return retry.NewRetrier(map[string]retry.Policy{"op": policy}, policy)
+}
+
+func TestRetriesTransientErrorsUntilSuccess(t *testing.T) {
+	r := fastRetrier(retry.Policy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Idempotent: true})
+
+	calls := 0
+	err := r.Do(context.Background(), "op", func() error {
+		calls++
+		if calls < 3 {
+			return apiError(http.StatusServiceUnavailable, "backendError")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Esperado sucesso após retentativas, obtido %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Esperado 3 chamadas, obtido %d", calls)
+	}
+}
+
+func TestStopsAtMaxAttempts(t *testing.T) {
+	r := fastRetrier(retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Idempotent: true})
+
+	calls := 0
+	err := r.Do(context.Background(), "op", func() error {
+		calls++
+		return apiError(http.StatusTooManyRequests, "")
+	})
+
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		t.Fatalf("Esperado erro da API preservado, obtido %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Esperado 3 chamadas, obtido %d", calls)
+	}
+}
+
+func TestDoesNotRetryPermanentErrors(t *testing.T) {
+	r := fastRetrier(retry.Policy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Idempotent: true})
+
+	calls := 0
+	_ = r.Do(context.Background(), "op", func() error {
+		calls++
+		return apiError(http.StatusForbidden, "quotaExceeded")
+	})
+
+	if calls != 1 {
+		t.Errorf("Esperado 1 chamada para quotaExceeded, obtido %d", calls)
+	}
+}
+
+func TestNonIdempotentOnlyRetriesRateLimits(t *testing.T) {
+	policy := retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	if policy.ShouldRetry(apiError(http.StatusInternalServerError, "backendError")) {
+		t.Errorf("Inserção não deveria ser repetida em 5xx")
+	}
+	if !policy.ShouldRetry(apiError(http.StatusForbidden, "rateLimitExceeded")) {
+		t.Errorf("Inserção deveria ser repetida em rateLimitExceeded")
+	}
+}
+
+func TestDelayHonorsRetryAfter(t *testing.T) {
+	policy := retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Second, Idempotent: true}
+
+	err := apiError(http.StatusTooManyRequests, "")
+	err.(*googleapi.Error).Header.Set("Retry-After", "2")
+
+	delay, ok := policy.Delay(1, err)
+	if !ok || delay < 2*time.Second {
+		t.Errorf("Esperado espera de ao menos 2s, obtido %v (ok=%v)", delay, ok)
+	}
+
+	err.(*googleapi.Error).Header.Set("Retry-After", "60")
+	if _, ok := policy.Delay(1, err); ok {
+		t.Errorf("Esperado desistir quando Retry-After excede MaxDelay")
+	}
+}
+
+func TestStopsWhenContextIsCancelled(t *testing.T) {
+	r := fastRetrier(retry.Policy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second, Idempotent: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := r.Do(ctx, "op", func() error {
+		return apiError(http.StatusServiceUnavailable, "")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Esperado context.Canceled, obtido %v", err)
+	}
+}
@@ -21,12 +21,20 @@ func ConfigureRoutes(
 	authHandler handlers.AuthHandler,
 	reorder handlers.ReorderPlaylistHandlerInterface,
 	getAll handlers.GetAllPlaylistsHandlerInterface,
+	playlistVideos handlers.GetPlaylistVideosHandlerInterface,
+	quotaHandler handlers.QuotaHandlerInterface,
+	statusHandler handlers.StatusHandlerInterface,
+	cacheAdmin handlers.CacheAdminHandlerInterface,
+	deadLetters handlers.DeadLetterHandlerInterface,
+	jobStatus handlers.JobStatusHandlerInterface,
+	adminToken string,
 	store sessions.SessionManager,
 	authService services.AuthService,
 	repo repository.UserRepositoryInterface,
 ) http.Handler {
 	// Cria o router principal
 	router := mux.NewRouter()
+	router.Use(middleware.RequestContextHandler)
 
 	// Rotas de autenticação (normalmente públicas)
 	router.HandleFunc("/auth/{provider}", authHandler.OAuthLogin).Methods("GET")
@@ -39,16 +47,46 @@ func ConfigureRoutes(
 
 	protected.HandleFunc("/reorder", reorder.ReorderPlaylist).Methods("POST")
 	protected.HandleFunc("/all", getAll.GetAllPlaylists).Methods("GET")
+	protected.HandleFunc("/{id}/videos", playlistVideos.GetPlaylistVideos).Methods("GET")
 	protected.HandleFunc("/validate", func(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(map[string]bool{"valid": true})
 	}).Methods("GET")
 
+	// Andamento das reordenações assíncronas
+	jobRoutes := router.PathPrefix("/jobs").Subrouter()
+	jobRoutes.Use(authMiddleware.ValidateTokenHandler)
+	jobRoutes.HandleFunc("/{id}", jobStatus.GetJob).Methods("GET")
+
+	quotaRoutes := router.PathPrefix("/quota").Subrouter()
+	quotaRoutes.Use(authMiddleware.ValidateTokenHandler)
+	quotaRoutes.HandleFunc("", quotaHandler.GetUsage).Methods("GET")
+	quotaRoutes.HandleFunc("/estimate", quotaHandler.Estimate).Methods("GET")
+
+	router.HandleFunc("/status/circuit-breakers", statusHandler.CircuitBreakers).Methods("GET")
+
+	// Administração do cache, protegida por ADMIN_TOKEN
+	adminRoutes := router.PathPrefix("/admin/cache").Subrouter()
+	adminRoutes.Use(middleware.AdminTokenHandler(adminToken))
+	adminRoutes.HandleFunc("/metrics", cacheAdmin.Metrics).Methods("GET")
+	adminRoutes.HandleFunc("/users/{userId}", cacheAdmin.UserCache).Methods("GET")
+	adminRoutes.HandleFunc("/users/{userId}", cacheAdmin.EvictUser).Methods("DELETE")
+
+	// Inspeção da fila de mensagens mortas, com a mesma proteção
+	deadLetterRoutes := router.PathPrefix("/admin/dead-letters").Subrouter()
+	deadLetterRoutes.Use(middleware.AdminTokenHandler(adminToken))
+	deadLetterRoutes.HandleFunc("", deadLetters.List).Methods("GET")
+	deadLetterRoutes.HandleFunc("", deadLetters.DiscardAll).Methods("DELETE")
+	deadLetterRoutes.HandleFunc("/replay", deadLetters.ReplayAll).Methods("POST")
+	deadLetterRoutes.HandleFunc("/{id}/replay", deadLetters.Replay).Methods("POST")
+	deadLetterRoutes.HandleFunc("/{id}", deadLetters.Discard).Methods("DELETE")
+
 	// Configuração de CORS
 	corsOption := handlers2.AllowedOrigins([]string{"http://localhost:5173"})
 	corsMethods := handlers2.AllowedMethods([]string{"GET", "POST", "OPTIONS", "PUT", "DELETE"})
-	corsHeaders := handlers2.AllowedHeaders([]string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-User-Id"})
+	corsHeaders := handlers2.AllowedHeaders([]string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-User-Id", middleware.RequestIdHeader, middleware.IdempotencyKeyHeader})
+	corsExposed := handlers2.ExposedHeaders([]string{middleware.RequestIdHeader, "Location"})
 	corsCredentials := handlers2.AllowCredentials()
-	corsHandler := handlers2.CORS(corsOption, corsMethods, corsHeaders, corsCredentials)(router)
+	corsHandler := handlers2.CORS(corsOption, corsMethods, corsHeaders, corsExposed, corsCredentials)(router)
 
 	return corsHandler
 }
@@ -0,0 +1,53 @@
+package workerpool
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrency é usado quando nenhuma concorrência válida é configurada.
+const DefaultConcurrency = 4
+
+// Map aplica fn a cada entrada com no máximo concurrency execuções simultâneas.
+//
+// O resultado de índice i corresponde à entrada i, independente da ordem em que as
+// execuções terminam. O primeiro erro cancela o ctx repassado às execuções restantes,
+// que deixam de ser iniciadas, e é o erro retornado. O cancelamento do ctx do chamador
+// tem o mesmo efeito.
+func Map[T, R any](ctx context.Context, concurrency int, inputs []T, fn func(ctx context.Context, input T) (R, error)) ([]R, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]R, len(inputs))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+
+	for i, input := range inputs {
+		// Go bloqueia enquanto o limite estiver cheio; depois de um erro não vale iniciar mais nada.
+		if groupCtx.Err() != nil {
+			break
+		}
+		group.Go(func() error {
+			if err := groupCtx.Err(); err != nil {
+				return err
+			}
+			result, err := fn(groupCtx, input)
+			if err != nil {
+				return err
+			}
+			results[i] = result
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	// Cancelado antes de qualquer execução falhar: os resultados estão incompletos.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,79 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"project/internal/infrastructure/workerpool"
+)
+
+func TestMapKeepsInputOrderAndLimitsConcurrency(t *testing.T) {
+	inputs := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	var running, peak atomic.Int32
+
+	results, err := workerpool.Map(context.Background(), 3, inputs, func(ctx context.Context, n int) (int, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		// Entradas menores demoram mais, para que terminem fora de ordem.
+		time.Sleep(time.Duration(10-n) * time.Millisecond)
+		return n * 10, nil
+	})
+
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	for i, n := range inputs {
+		if results[i] != n*10 {
+			t.Fatalf("Resultado %d fora de ordem: esperado %d, obtido %d", i, n*10, results[i])
+		}
+	}
+	if peak.Load() > 3 {
+		t.Errorf("Esperado no máximo 3 execuções simultâneas, obtido %d", peak.Load())
+	}
+}
+
+func TestMapCancelsOnFirstError(t *testing.T) {
+	errFatal := errors.New("fatal")
+	var started atomic.Int32
+
+	_, err := workerpool.Map(context.Background(), 2, make([]int, 20), func(ctx context.Context, _ int) (int, error) {
+		if started.Add(1) == 1 {
+			return 0, errFatal
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(time.Second):
+			return 0, nil
+		}
+	})
+
+	if !errors.Is(err, errFatal) {
+		t.Fatalf("Esperado o primeiro erro, obtido %v", err)
+	}
+	if started.Load() >= 20 {
+		t.Errorf("Após o erro nenhuma nova execução deveria começar, iniciadas %d", started.Load())
+	}
+}
+
+func TestMapStopsWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := workerpool.Map(ctx, 2, []int{1, 2, 3}, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Esperado context.Canceled, obtido %v", err)
+	}
+}